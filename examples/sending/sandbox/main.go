@@ -7,6 +7,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -38,7 +39,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	resp, _, err := client.Send(emailRequest())
+	resp, _, err := client.Send(context.Background(), emailRequest())
 	if err != nil {
 		log.Fatalf("Error sending email: %v", err)
 	}
@@ -83,7 +84,7 @@ func emailRequest() *mailtrap.SendEmailRequest {
 			"user_id":  "1",
 			"batch_id": "2",
 		},
-		Headers: map[string]string{
+		Headers: map[mailtrap.HeaderName]string{
 			"X-Message-Source": "mail.example.com",
 		},
 		Subject:  "API Client Test",
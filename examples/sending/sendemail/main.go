@@ -7,6 +7,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -23,7 +24,7 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	resp, _, err := client.Send(emailRequest())
+	resp, _, err := client.Send(context.Background(), emailRequest())
 	if err != nil {
 		log.Fatalf("Error sending email: %v", err)
 	}
@@ -68,7 +69,7 @@ func emailRequest() *mailtrap.SendEmailRequest {
 			"user_id":  "1",
 			"batch_id": "2",
 		},
-		Headers: map[string]string{
+		Headers: map[mailtrap.HeaderName]string{
 			"X-Message-Source": "mail.example.com",
 		},
 		Subject:  "API Client Test",
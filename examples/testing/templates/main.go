@@ -0,0 +1,35 @@
+// An example of how to use Template methods.
+//
+// It's runnable with the following command:
+// export MAILTRAP_API_KEY=your_api_key
+// go run .
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/bennovw/mailtrap-go/mailtrap"
+)
+
+func main() {
+	apiKey := os.Getenv("MAILTRAP_API_KEY")
+	if apiKey == "" {
+		log.Fatal("No API key present")
+	}
+	client, _ := mailtrap.NewTestingClient(apiKey)
+
+	var accountID int
+	fmt.Print("Enter an accountID value: ")
+	fmt.Scanf("%d", &accountID)
+
+	templates, _, err := client.Templates.List(accountID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, v := range templates {
+		fmt.Println("ID:", v.ID, "\nUUID:", v.UUID, "\nName:", v.Name, "\nSubject:", v.Subject, "\nCategory:", v.Category)
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/bennovw/mailtrap-go
 
 go 1.21
+
+require golang.org/x/time v0.5.0
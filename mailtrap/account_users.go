@@ -1,13 +1,17 @@
 package mailtrap
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 type AccountUsersServiceContract interface {
 	List(accountID int, params *ListAccountUsersParams) ([]*AccountUser, *Response, error)
+	ListWithContext(ctx context.Context, accountID int, params *ListAccountUsersParams) ([]*AccountUser, *Response, error)
 	Delete(accountID, accountAccessID int) (*Response, error)
+	DeleteWithContext(ctx context.Context, accountID, accountAccessID int) (*Response, error)
 }
 
 type AccountUsersService struct {
@@ -24,6 +28,72 @@ type AccountUser struct {
 	Resources     []AccountUserResources `json:"resources"`
 	Specifier     AccountUserSpecifier   `json:"specifier"`
 	Permissions   Permissions            `json:"permissions"`
+	CreatedAt     time.Time              `json:"created_at"`
+}
+
+// Account user roles, derived from the access level on the account resource.
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleViewer = "viewer"
+)
+
+// Email returns the email address of the user or invitee.
+func (u *AccountUser) Email() string {
+	return u.Specifier.Email
+}
+
+// Role returns the role of the user on the account, or an empty string
+// if the user has no access to the account resource itself.
+func (u *AccountUser) Role() string {
+	for _, r := range u.Resources {
+		if r.ResourceType != "account" {
+			continue
+		}
+		switch {
+		case r.AccessLevel >= AccessLevelOwner:
+			return RoleOwner
+		case r.AccessLevel >= AccessLevelAdmin:
+			return RoleAdmin
+		case r.AccessLevel >= AccessLevelViewer:
+			return RoleViewer
+		}
+	}
+
+	return ""
+}
+
+// IsOwner reports whether the user is the account owner.
+func (u *AccountUser) IsOwner() bool {
+	return u.Role() == RoleOwner
+}
+
+// IsAdmin reports whether the user is an account admin.
+func (u *AccountUser) IsAdmin() bool {
+	return u.Role() == RoleAdmin
+}
+
+// actionAccessLevels maps resource actions to the minimum access level they require.
+var actionAccessLevels = map[string]int{
+	"read":    AccessLevelViewer,
+	"update":  AccessLevelAdmin,
+	"destroy": AccessLevelAdmin,
+}
+
+// HasPermission reports whether the user has access to perform the action ("read", "update" or "destroy")
+// on at least one resource of the given type, e.g. "inbox" or "project".
+func (u *AccountUser) HasPermission(resourceType, action string) bool {
+	level, ok := actionAccessLevels[action]
+	if !ok {
+		return false
+	}
+	for _, r := range u.Resources {
+		if r.ResourceType == resourceType && r.AccessLevel >= level {
+			return true
+		}
+	}
+
+	return false
 }
 
 // AccountUserResources represents a Mailtrap account users resources.
@@ -57,15 +127,24 @@ type ListAccountUsersParams struct {
 func (s *AccountUsersService) List(
 	accountID int,
 	params *ListAccountUsersParams,
+) ([]*AccountUser, *Response, error) {
+	return s.ListWithContext(context.Background(), accountID, params)
+}
+
+// ListWithContext is like List but uses the given context for the request.
+func (s *AccountUsersService) ListWithContext(
+	ctx context.Context,
+	accountID int,
+	params *ListAccountUsersParams,
 ) ([]*AccountUser, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/account_accesses", accountID)
-	req, err := s.client.NewRequest(http.MethodGet, u, params)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, u, params)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var accUser []*AccountUser
-	res, err := s.client.Do(req, &accUser)
+	res, err := s.client.Do(ctx, req, &accUser)
 	if err != nil {
 		return nil, res, err
 	}
@@ -78,11 +157,16 @@ func (s *AccountUsersService) List(
 //
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/569947e980f71-remove-user-from-the-account
 func (s *AccountUsersService) Delete(accountID, accountAccessID int) (*Response, error) {
+	return s.DeleteWithContext(context.Background(), accountID, accountAccessID)
+}
+
+// DeleteWithContext is like Delete but uses the given context for the request.
+func (s *AccountUsersService) DeleteWithContext(ctx context.Context, accountID, accountAccessID int) (*Response, error) {
 	u := fmt.Sprintf("/accounts/%d/account_accesses/%d", accountID, accountAccessID)
-	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Do(req, nil)
+	return s.client.Do(ctx, req, nil)
 }
@@ -1,10 +1,12 @@
 package mailtrap
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestAccountUsersService_Marshal(t *testing.T) {
@@ -57,6 +59,87 @@ func TestAccountUsersService_Marshal(t *testing.T) {
 	testJSONMarshal(t, u, want)
 }
 
+func TestAccountUser_Unmarshal(t *testing.T) {
+	data := `{
+		"id": 1,
+		"specifier_type": "user",
+		"specifier": {"id": 3, "email": "jd@example.com", "name": "John"},
+		"resources": [
+			{"resource_type": "account", "resource_id": 2, "access_level": 100},
+			{"resource_type": "inbox", "resource_id": 4, "access_level": 10}
+		],
+		"created_at": "2023-02-14T19:29:59Z"
+	}`
+
+	var got AccountUser
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if got.Email() != "jd@example.com" {
+		t.Errorf("AccountUser.Email() = %q, expected %q", got.Email(), "jd@example.com")
+	}
+	if got.Role() != RoleAdmin {
+		t.Errorf("AccountUser.Role() = %q, expected %q", got.Role(), RoleAdmin)
+	}
+	if want := time.Date(2023, 2, 14, 19, 29, 59, 0, time.UTC); !got.CreatedAt.Equal(want) {
+		t.Errorf("AccountUser.CreatedAt = %v, expected %v", got.CreatedAt, want)
+	}
+}
+
+func TestAccountUser_Roles(t *testing.T) {
+	tests := []struct {
+		level            int
+		role             string
+		isOwner, isAdmin bool
+	}{
+		{level: AccessLevelOwner, role: RoleOwner, isOwner: true},
+		{level: AccessLevelAdmin, role: RoleAdmin, isAdmin: true},
+		{level: AccessLevelViewer, role: RoleViewer},
+	}
+	for _, tt := range tests {
+		u := &AccountUser{Resources: []AccountUserResources{{ResourceType: "account", AccessLevel: tt.level}}}
+		if got := u.Role(); got != tt.role {
+			t.Errorf("AccountUser.Role() with level %d = %q, expected %q", tt.level, got, tt.role)
+		}
+		if got := u.IsOwner(); got != tt.isOwner {
+			t.Errorf("AccountUser.IsOwner() with level %d = %v, expected %v", tt.level, got, tt.isOwner)
+		}
+		if got := u.IsAdmin(); got != tt.isAdmin {
+			t.Errorf("AccountUser.IsAdmin() with level %d = %v, expected %v", tt.level, got, tt.isAdmin)
+		}
+	}
+
+	u := &AccountUser{Resources: []AccountUserResources{{ResourceType: "inbox", AccessLevel: AccessLevelOwner}}}
+	if got := u.Role(); got != "" {
+		t.Errorf("AccountUser.Role() without account access = %q, expected empty", got)
+	}
+}
+
+func TestAccountUser_HasPermission(t *testing.T) {
+	u := &AccountUser{Resources: []AccountUserResources{
+		{ResourceType: "project", ResourceID: 1, AccessLevel: AccessLevelAdmin},
+		{ResourceType: "inbox", ResourceID: 2, AccessLevel: AccessLevelViewer},
+	}}
+
+	tests := []struct {
+		resource, action string
+		expected         bool
+	}{
+		{"project", "read", true},
+		{"project", "update", true},
+		{"project", "destroy", true},
+		{"inbox", "read", true},
+		{"inbox", "update", false},
+		{"billing", "read", false},
+		{"project", "transfer", false},
+	}
+	for _, tt := range tests {
+		if got := u.HasPermission(tt.resource, tt.action); got != tt.expected {
+			t.Errorf("AccountUser.HasPermission(%q, %q) = %v, expected %v", tt.resource, tt.action, got, tt.expected)
+		}
+	}
+}
+
 func TestAccountUsersService_List(t *testing.T) {
 	client, mux, teardown := setupTestingClient()
 	defer teardown()
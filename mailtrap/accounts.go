@@ -1,9 +1,16 @@
 package mailtrap
 
-import "net/http"
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
 
 type AccountsServiceContract interface {
 	List() ([]*Account, *Response, error)
+	ListWithContext(ctx context.Context) ([]*Account, *Response, error)
+	GetUsage(accountID int) (*UsageStats, *Response, error)
+	GetUsageWithContext(ctx context.Context, accountID int) (*UsageStats, *Response, error)
 }
 
 type AccountsService struct {
@@ -12,27 +19,113 @@ type AccountsService struct {
 
 var _ AccountsServiceContract = &AccountsService{}
 
+// Access levels of account users and API tokens.
+const (
+	AccessLevelOwner  = 1000
+	AccessLevelAdmin  = 100
+	AccessLevelViewer = 10
+)
+
 // Account represents a Mailtrap account schema.
+// The API returns the caller's access as a list of numeric levels, so they are kept in AccessLevels
+// rather than a single AccessLevel string; compare them with AccessLevelOwner and friends.
 type Account struct {
-	ID           int    `json:"id"`
-	Name         string `json:"name"`
-	AccessLevels []int  `json:"access_levels"`
+	ID           int      `json:"id"`
+	Name         string   `json:"name"`
+	AccessLevels []int    `json:"access_levels"`
+	BillingEmail string   `json:"billing_email"`
+	Plan         PlanInfo `json:"plan"`
+}
+
+// PlanInfo represents the subscription plan of an account and its limits.
+type PlanInfo struct {
+	Name    string `json:"name"`
+	Emails  int    `json:"emails"`
+	Inboxes int    `json:"inboxes"`
+}
+
+// IsOwner reports whether the caller has owner access to the account.
+func (a *Account) IsOwner() bool {
+	for _, level := range a.AccessLevels {
+		if level == AccessLevelOwner {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasCapacity reports whether the monthly emails sent are below the plan email limit.
+// The accounts endpoint does not return usage, so it takes the stats from AccountsService.GetUsage
+// instead of being a method without arguments. A nil usage counts as nothing sent.
+func (a *Account) HasCapacity(usage *UsageStats) bool {
+	var sent int
+	if usage != nil {
+		sent = usage.MonthlySent
+	}
+
+	return sent < a.Plan.Emails
+}
+
+// UsageStats represents the sending quota usage of an account.
+type UsageStats struct {
+	DailySent      int  `json:"daily_sent"`
+	DailyLimit     int  `json:"daily_limit"`
+	MonthlySent    int  `json:"monthly_sent"`
+	MonthlyLimit   int  `json:"monthly_limit"`
+	OverageAllowed bool `json:"overage_allowed"`
+}
+
+// UsagePercent returns the percentage of the daily limit used, or 0 if there is no daily limit.
+func (u *UsageStats) UsagePercent() float64 {
+	if u.DailyLimit == 0 {
+		return 0
+	}
+
+	return float64(u.DailySent) / float64(u.DailyLimit) * 100
 }
 
 // List returns a list of Mailtrap accounts.
 //
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/d26921ca2a48f-get-all-accounts
 func (s *AccountsService) List() ([]*Account, *Response, error) {
-	req, err := s.client.NewRequest(http.MethodGet, "/accounts", nil)
+	return s.ListWithContext(context.Background())
+}
+
+// ListWithContext is like List but uses the given context for the request.
+func (s *AccountsService) ListWithContext(ctx context.Context) ([]*Account, *Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, "/accounts", nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var accounts []*Account
-	res, err := s.client.Do(req, &accounts)
+	res, err := s.client.Do(ctx, req, &accounts)
 	if err != nil {
 		return nil, res, err
 	}
 
 	return accounts, res, nil
 }
+
+// GetUsage returns the sending quota usage of the account.
+func (s *AccountsService) GetUsage(accountID int) (*UsageStats, *Response, error) {
+	return s.GetUsageWithContext(context.Background(), accountID)
+}
+
+// GetUsageWithContext is like GetUsage but uses the given context for the request.
+func (s *AccountsService) GetUsageWithContext(ctx context.Context, accountID int) (*UsageStats, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/usage", accountID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var usage *UsageStats
+	res, err := s.client.Do(ctx, req, &usage)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return usage, res, nil
+}
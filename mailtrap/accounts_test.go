@@ -26,6 +26,60 @@ func TestAccountsService_Marshal(t *testing.T) {
 	testJSONMarshal(t, u, want)
 }
 
+func TestAccount_Unmarshal(t *testing.T) {
+	data := `{
+		"id": 1,
+		"name": "account-1",
+		"access_levels": [1000],
+		"billing_email": "billing@example.com",
+		"plan": {"name": "Business", "emails": 100000, "inboxes": 50}
+	}`
+
+	var got Account
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	expected := Account{
+		ID:           1,
+		Name:         "account-1",
+		AccessLevels: []int{AccessLevelOwner},
+		BillingEmail: "billing@example.com",
+		Plan:         PlanInfo{Name: "Business", Emails: 100000, Inboxes: 50},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("json.Unmarshal returned %+v, expected %+v", got, expected)
+	}
+}
+
+func TestAccount_IsOwner(t *testing.T) {
+	if owner := (&Account{AccessLevels: []int{AccessLevelOwner}}); !owner.IsOwner() {
+		t.Error("Account.IsOwner() = false, expected true")
+	}
+	if admin := (&Account{AccessLevels: []int{AccessLevelAdmin, AccessLevelViewer}}); admin.IsOwner() {
+		t.Error("Account.IsOwner() = true, expected false")
+	}
+}
+
+func TestAccount_HasCapacity(t *testing.T) {
+	account := &Account{Plan: PlanInfo{Emails: 1000}}
+
+	tests := []struct {
+		usage    *UsageStats
+		expected bool
+	}{
+		{usage: nil, expected: true},
+		{usage: &UsageStats{}, expected: true},
+		{usage: &UsageStats{MonthlySent: 999}, expected: true},
+		{usage: &UsageStats{MonthlySent: 1000}, expected: false},
+	}
+	for _, tt := range tests {
+		if got := account.HasCapacity(tt.usage); got != tt.expected {
+			t.Errorf("Account.HasCapacity(%+v) = %v, expected %v", tt.usage, got, tt.expected)
+		}
+	}
+}
+
 func TestAccountsService_List(t *testing.T) {
 	client, mux, teardown := setupTestingClient()
 	defer teardown()
@@ -67,3 +121,61 @@ func TestAccountsService_List(t *testing.T) {
 		return resp, err
 	})
 }
+
+func TestAccountsService_GetUsage(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		expected    *UsageStats
+		wantPercent float64
+	}{
+		{
+			name: "full usage",
+			body: `{"daily_sent":1000,"daily_limit":1000,"monthly_sent":20000,"monthly_limit":50000,"overage_allowed":true}`,
+			expected: &UsageStats{
+				DailySent: 1000, DailyLimit: 1000, MonthlySent: 20000, MonthlyLimit: 50000, OverageAllowed: true,
+			},
+			wantPercent: 100,
+		},
+		{
+			name:        "zero limit",
+			body:        `{"daily_sent":10,"daily_limit":0}`,
+			expected:    &UsageStats{DailySent: 10},
+			wantPercent: 0,
+		},
+		{
+			name:        "null limit",
+			body:        `{"daily_sent":10,"daily_limit":null}`,
+			expected:    &UsageStats{DailySent: 10},
+			wantPercent: 0,
+		},
+		{
+			name:        "missing limit",
+			body:        `{"daily_sent":10}`,
+			expected:    &UsageStats{DailySent: 10},
+			wantPercent: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, teardown := setupTestingClient()
+			defer teardown()
+
+			mux.HandleFunc("/accounts/1/usage", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				fmt.Fprint(w, tt.body)
+			})
+
+			usage, _, err := client.Accounts.GetUsage(1)
+			if err != nil {
+				t.Fatalf("Accounts.GetUsage returned error: %v", err)
+			}
+			if !reflect.DeepEqual(usage, tt.expected) {
+				t.Errorf("Accounts.GetUsage returned %+v, expected %+v", usage, tt.expected)
+			}
+			if got := usage.UsagePercent(); got != tt.wantPercent {
+				t.Errorf("UsageStats.UsagePercent() = %v, expected %v", got, tt.wantPercent)
+			}
+		})
+	}
+}
@@ -1,13 +1,19 @@
 package mailtrap
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"net/http"
 )
 
 type AttachmentsServiceContract interface {
 	List(accountID, inboxID, messageID int) ([]*Attachment, *Response, error)
+	ListWithContext(ctx context.Context, accountID, inboxID, messageID int) ([]*Attachment, *Response, error)
 	Get(accountID, inboxID, messageID, attachmentID int) (*Attachment, *Response, error)
+	GetWithContext(ctx context.Context, accountID, inboxID, messageID, attachmentID int) (*Attachment, *Response, error)
+	GetAsReader(ctx context.Context, accountID, inboxID, messageID, attachmentID int) (io.Reader, *Response, error)
 }
 
 type AttachmentsService struct {
@@ -32,20 +38,38 @@ type Attachment struct {
 	DownloadPath        string `json:"download_path"`
 }
 
+// IsInline reports whether the attachment is an inline attachment, e.g. an image embedded in the HTML body.
+func (a *Attachment) IsInline() bool {
+	return a.AttachmentType == "inline"
+}
+
+// MIMEType returns the MIME type of the attachment content.
+func (a *Attachment) MIMEType() string {
+	return a.ContentType
+}
+
 // List returns message attachments by inboxID and messageID.
 //
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/bcb1ef001e32d-get-attachments
 func (s *AttachmentsService) List(
 	accountID, inboxID, messageID int,
+) ([]*Attachment, *Response, error) {
+	return s.ListWithContext(context.Background(), accountID, inboxID, messageID)
+}
+
+// ListWithContext is like List but uses the given context for the request.
+func (s *AttachmentsService) ListWithContext(
+	ctx context.Context,
+	accountID, inboxID, messageID int,
 ) ([]*Attachment, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d/attachments", accountID, inboxID, messageID)
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var attach []*Attachment
-	resp, err := s.client.Do(req, &attach)
+	resp, err := s.client.Do(ctx, req, &attach)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -58,18 +82,63 @@ func (s *AttachmentsService) List(
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/e2e15ad4475a4-get-single-attachment
 func (s *AttachmentsService) Get(
 	accountID, inboxID, messageID, attachmentID int,
+) (*Attachment, *Response, error) {
+	return s.GetWithContext(context.Background(), accountID, inboxID, messageID, attachmentID)
+}
+
+// GetWithContext is like Get but uses the given context for the request.
+func (s *AttachmentsService) GetWithContext(
+	ctx context.Context,
+	accountID, inboxID, messageID, attachmentID int,
 ) (*Attachment, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d/attachments/%d", accountID, inboxID, messageID, attachmentID)
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var attach *Attachment
-	res, err := s.client.Do(req, &attach)
+	res, err := s.client.Do(ctx, req, &attach)
 	if err != nil {
 		return nil, res, err
 	}
 
 	return attach, res, err
 }
+
+// GetAsReader downloads the raw content of a message attachment.
+func (s *AttachmentsService) GetAsReader(
+	ctx context.Context,
+	accountID, inboxID, messageID, attachmentID int,
+) (io.Reader, *Response, error) {
+	u := fmt.Sprintf(
+		"/accounts/%d/inboxes/%d/messages/%d/attachments/%d/download",
+		accountID, inboxID, messageID, attachmentID,
+	)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var data []byte
+	res, err := s.client.Do(ctx, req, &data)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return bytes.NewReader(data), res, nil
+}
+
+// DownloadContent fetches the raw content of the attachment.
+func (a *Attachment) DownloadContent(
+	ctx context.Context,
+	client *TestingClient,
+	accountID, inboxID, messageID int,
+) ([]byte, error) {
+	r, _, err := client.Attachments.GetAsReader(ctx, accountID, inboxID, messageID, a.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(r)
+}
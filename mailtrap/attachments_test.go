@@ -1,7 +1,9 @@
 package mailtrap
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -29,6 +31,61 @@ func TestAttachmentsService_Marshal(t *testing.T) {
 	testJSONMarshal(t, u, want)
 }
 
+func TestAttachment_Unmarshal(t *testing.T) {
+	data := `{
+		"id": 4,
+		"message_id": 3,
+		"filename": "logo.png",
+		"attachment_type": "inline",
+		"content_type": "image/png",
+		"content_id": "<logo@example.com>",
+		"transfer_encoding": "base64",
+		"attachment_size": 2048,
+		"created_at": "2023-02-13T21:05:55.687Z",
+		"updated_at": "2023-02-13T21:05:55.687Z",
+		"attachment_human_size": "2 KB",
+		"download_path": "/api/accounts/1/inboxes/2/messages/3/attachments/4/download"
+	}`
+
+	var got Attachment
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	expected := Attachment{
+		ID:                  4,
+		MessageID:           3,
+		Filename:            "logo.png",
+		AttachmentType:      "inline",
+		ContentType:         "image/png",
+		ContentID:           "<logo@example.com>",
+		TransferEncoding:    "base64",
+		AttachmentSize:      2048,
+		CreatedAt:           "2023-02-13T21:05:55.687Z",
+		UpdatedAt:           "2023-02-13T21:05:55.687Z",
+		AttachmentHumanSize: "2 KB",
+		DownloadPath:        "/api/accounts/1/inboxes/2/messages/3/attachments/4/download",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("json.Unmarshal returned %+v, expected %+v", got, expected)
+	}
+
+	if !got.IsInline() {
+		t.Error("Attachment.IsInline() = false, expected true")
+	}
+	if got.MIMEType() != "image/png" {
+		t.Errorf("Attachment.MIMEType() = %q, expected %q", got.MIMEType(), "image/png")
+	}
+
+	regular := &Attachment{AttachmentType: "attachment", ContentType: "application/pdf"}
+	if regular.IsInline() {
+		t.Error("Attachment.IsInline() = true, expected false")
+	}
+	if regular.MIMEType() != "application/pdf" {
+		t.Errorf("Attachment.MIMEType() = %q, expected %q", regular.MIMEType(), "application/pdf")
+	}
+}
+
 func TestAttachmentsService_List(t *testing.T) {
 	client, mux, teardown := setupTestingClient()
 	defer teardown()
@@ -69,6 +126,23 @@ func TestAttachmentsService_List(t *testing.T) {
 	}
 }
 
+func TestAttachmentsService_ListWithContext_canceled(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/attachments", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Attachments.ListWithContext sent a request with a canceled context")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := client.Attachments.ListWithContext(ctx, 1, 2, 3)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Attachments.ListWithContext returned error %v, expected %v", err, context.Canceled)
+	}
+}
+
 func TestAttachmentsService_Get(t *testing.T) {
 	client, mux, teardown := setupTestingClient()
 	defer teardown()
@@ -109,6 +183,28 @@ func TestAttachmentsService_Get(t *testing.T) {
 	}
 }
 
+func TestAttachment_DownloadContent(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/attachments/4/download", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, "id,name\n1,test\n")
+	})
+
+	content, err := attachment(4).DownloadContent(context.Background(), client, 1, 2, 3)
+	if err != nil {
+		t.Fatalf("Attachment.DownloadContent returned error: %v", err)
+	}
+	if want := "id,name\n1,test\n"; string(content) != want {
+		t.Errorf("Attachment.DownloadContent returned %q, expected %q", content, want)
+	}
+
+	if _, err := attachment(5).DownloadContent(context.Background(), client, 1, 2, 3); err == nil {
+		t.Error("Attachment.DownloadContent for missing attachment err = nil, want error")
+	}
+}
+
 func TestAttachmentsService_Get_notFound(t *testing.T) {
 	t.Skip()
 }
@@ -0,0 +1,88 @@
+package mailtrap
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditLogFormat selects the format of the entries written by WithAuditLog.
+type AuditLogFormat int
+
+const (
+	// AuditLogJSON writes every entry as a JSON object on its own line.
+	AuditLogJSON AuditLogFormat = iota
+	// AuditLogCSV writes every entry as a CSV record with the columns
+	// timestamp, from, recipients, subject, category and message_ids.
+	// Multiple message IDs are separated by spaces.
+	AuditLogCSV
+)
+
+// auditLogSubjectMaxLength is the number of characters of the subject kept in audit log entries.
+const auditLogSubjectMaxLength = 100
+
+// AuditLogEntry is a record of a successfully sent email.
+type AuditLogEntry struct {
+	Timestamp  time.Time `json:"timestamp"`
+	From       string    `json:"from"`
+	Recipients int       `json:"recipients"`
+	Subject    string    `json:"subject"`
+	Category   string    `json:"category,omitempty"`
+	MessageIDs []string  `json:"message_ids"`
+}
+
+// auditLogger writes an entry for every successfully sent email to w.
+type auditLogger struct {
+	format AuditLogFormat
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func newAuditLogEntry(r *SendEmailRequest, resp *SendEmailResponse) AuditLogEntry {
+	subject := r.Subject
+	if runes := []rune(subject); len(runes) > auditLogSubjectMaxLength {
+		subject = string(runes[:auditLogSubjectMaxLength]) + "..."
+	}
+
+	return AuditLogEntry{
+		Timestamp:  time.Now().UTC(),
+		From:       r.From.Email,
+		Recipients: len(r.To) + len(r.Cc) + len(r.Bcc),
+		Subject:    subject,
+		Category:   r.Category,
+		MessageIDs: resp.MessageIDs,
+	}
+}
+
+// log writes the entry for the sent request. Write errors are ignored so that they do not
+// turn a successful send into a failure.
+func (l *auditLogger) log(r *SendEmailRequest, resp *SendEmailResponse) {
+	if l == nil {
+		return
+	}
+	e := newAuditLogEntry(r, resp)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch l.format {
+	case AuditLogCSV:
+		w := csv.NewWriter(l.w)
+		_ = w.Write([]string{
+			e.Timestamp.Format(time.RFC3339),
+			e.From,
+			strconv.Itoa(e.Recipients),
+			e.Subject,
+			e.Category,
+			strings.Join(e.MessageIDs, " "),
+		})
+		w.Flush()
+	default:
+		_ = json.NewEncoder(l.w).Encode(e)
+	}
+}
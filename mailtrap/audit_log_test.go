@@ -0,0 +1,107 @@
+package mailtrap
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithAuditLog_json(t *testing.T) {
+	var buf bytes.Buffer
+	client, mux, teardown := setupSendingClient(WithAuditLog(&buf, AuditLogJSON))
+	defer teardown()
+
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true,"message_ids":["1","2"]}`)
+	})
+
+	req := emailRequestMock()
+	req.Subject = strings.Repeat("s", 120)
+	if _, _, err := client.Send(context.Background(), req); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+
+	var entry AuditLogEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("audit log %q is not a JSON line: %v", buf.String(), err)
+	}
+	if time.Since(entry.Timestamp) > time.Minute {
+		t.Errorf("audit log timestamp = %v, want the current time", entry.Timestamp)
+	}
+	entry.Timestamp = time.Time{}
+	want := AuditLogEntry{
+		From:       "ches@example.com",
+		Recipients: 4,
+		Subject:    strings.Repeat("s", 100) + "...",
+		Category:   "API Client",
+		MessageIDs: []string{"1", "2"},
+	}
+	if !reflect.DeepEqual(entry, want) {
+		t.Errorf("audit log entry = %+v, want %+v", entry, want)
+	}
+}
+
+func TestWithAuditLog_csv(t *testing.T) {
+	var buf bytes.Buffer
+	client, mux, teardown := setupSandboxSendingClient(WithAuditLog(&buf, AuditLogCSV))
+	defer teardown()
+
+	mux.HandleFunc("/send/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true,"message_ids":["1","2"]}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Send(context.Background(), emailRequestMock()); err != nil {
+			t.Fatalf("Send returned error: %v", err)
+		}
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("audit log %q is not CSV: %v", buf.String(), err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("audit log has %d records, want 2", len(records))
+	}
+	if _, err := time.Parse(time.RFC3339, records[0][0]); err != nil {
+		t.Errorf("audit log timestamp %q is not RFC 3339: %v", records[0][0], err)
+	}
+	want := []string{"ches@example.com", "4", "Your Example Order Confirmation", "API Client", "1 2"}
+	if !reflect.DeepEqual(records[0][1:], want) {
+		t.Errorf("audit log record = %q, want %q", records[0][1:], want)
+	}
+}
+
+func TestWithAuditLog_failedSend(t *testing.T) {
+	var buf bytes.Buffer
+	client, mux, teardown := setupSendingClient(WithAuditLog(&buf, AuditLogJSON))
+	defer teardown()
+
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"success":false,"errors":["'from' address is invalid"]}`)
+	})
+
+	invalid := emailRequestMock()
+	invalid.Subject = ""
+	if _, _, err := client.Send(context.Background(), invalid); err == nil {
+		t.Error("Send returned no error for an invalid request")
+	}
+	if _, _, err := client.Send(context.Background(), emailRequestMock()); err == nil {
+		t.Error("Send returned no error for a rejected request")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("audit log = %q, want it empty", buf.String())
+	}
+
+	if _, err := NewSendingClient("api-token", WithAuditLog(&buf, AuditLogFormat(5))); err == nil {
+		t.Error("NewSendingClient with unknown audit log format err = nil, want error")
+	}
+}
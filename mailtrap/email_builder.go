@@ -0,0 +1,120 @@
+package mailtrap
+
+// EmailBuilder assembles a SendEmailRequest step by step, e.g.
+//
+//	req, err := mailtrap.NewEmailBuilder().
+//		From("ches@example.com", "Ches").
+//		To("john@example.com", "John Doe").
+//		Subject("Order confirmation").
+//		Text("Thank you for your order.").
+//		Build()
+//
+// Its methods return the builder itself for chaining.
+type EmailBuilder struct {
+	req SendEmailRequest
+}
+
+// NewEmailBuilder returns an empty email builder.
+func NewEmailBuilder() *EmailBuilder {
+	return &EmailBuilder{}
+}
+
+// From sets the sender address.
+func (b *EmailBuilder) From(email, name string) *EmailBuilder {
+	b.req.From = EmailAddress{Email: email, Name: name}
+	return b
+}
+
+// To adds a recipient.
+func (b *EmailBuilder) To(email, name string) *EmailBuilder {
+	b.req.To = append(b.req.To, EmailAddress{Email: email, Name: name})
+	return b
+}
+
+// Cc adds a carbon copy recipient.
+func (b *EmailBuilder) Cc(email, name string) *EmailBuilder {
+	b.req.Cc = append(b.req.Cc, EmailAddress{Email: email, Name: name})
+	return b
+}
+
+// Bcc adds a blind carbon copy recipient.
+func (b *EmailBuilder) Bcc(email, name string) *EmailBuilder {
+	b.req.Bcc = append(b.req.Bcc, EmailAddress{Email: email, Name: name})
+	return b
+}
+
+// Subject sets the subject.
+func (b *EmailBuilder) Subject(s string) *EmailBuilder {
+	b.req.Subject = s
+	return b
+}
+
+// Text sets the text body.
+func (b *EmailBuilder) Text(s string) *EmailBuilder {
+	b.req.Text = s
+	return b
+}
+
+// HTML sets the HTML body.
+func (b *EmailBuilder) HTML(s string) *EmailBuilder {
+	b.req.HTML = s
+	return b
+}
+
+// Category sets the category.
+func (b *EmailBuilder) Category(s string) *EmailBuilder {
+	b.req.Category = s
+	return b
+}
+
+// Header sets a custom header, replacing the value of a header with the same name.
+func (b *EmailBuilder) Header(k, v string) *EmailBuilder {
+	if b.req.Headers == nil {
+		b.req.Headers = make(map[HeaderName]string)
+	}
+	b.req.Headers[HeaderName(k)] = v
+	return b
+}
+
+// CustomVar sets a custom variable, replacing the value of a variable with the same key.
+func (b *EmailBuilder) CustomVar(k, v string) *EmailBuilder {
+	if b.req.CustomVars == nil {
+		b.req.CustomVars = make(map[string]string)
+	}
+	b.req.CustomVars[k] = v
+	return b
+}
+
+// Attach adds an attachment.
+func (b *EmailBuilder) Attach(a EmailAttachment) *EmailBuilder {
+	b.req.Attachments = append(b.req.Attachments, a)
+	return b
+}
+
+// Build validates the assembled request and returns a copy of it, so that later calls
+// on the builder do not change the returned request.
+// The error is the one returned by SendEmailRequest.Validate.
+func (b *EmailBuilder) Build() (*SendEmailRequest, error) {
+	if err := b.req.Validate(); err != nil {
+		return nil, err
+	}
+	req := b.req
+	req.To = append([]EmailAddress(nil), b.req.To...)
+	req.Cc = append([]EmailAddress(nil), b.req.Cc...)
+	req.Bcc = append([]EmailAddress(nil), b.req.Bcc...)
+	req.Attachments = append([]EmailAttachment(nil), b.req.Attachments...)
+	if b.req.Headers != nil {
+		req.Headers = make(map[HeaderName]string, len(b.req.Headers))
+		for k, v := range b.req.Headers {
+			req.Headers[k] = v
+		}
+	}
+	if b.req.CustomVars != nil {
+		req.CustomVars = make(map[string]string, len(b.req.CustomVars))
+		for k, v := range b.req.CustomVars {
+			req.CustomVars[k] = v
+		}
+	}
+
+	return &req, nil
+}
@@ -0,0 +1,79 @@
+package mailtrap
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEmailBuilder(t *testing.T) {
+	want := emailRequestMock()
+
+	got, err := NewEmailBuilder().
+		From("ches@example.com", "Ches").
+		To("johndoe@example.com", "John Doe").
+		To("mike@example.com", "Mike").
+		Cc("info@example.com", "Example LLC").
+		Bcc("dontreply@example.com", "").
+		Attach(want.Attachments[0]).
+		CustomVar("user_id", "1").
+		CustomVar("batch_id", "2").
+		Header("X-Message-Source", "mail.example.com").
+		Subject("Your Example Order Confirmation").
+		Text("Congratulations on your order no.123").
+		Category("API Client").
+		Build()
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Build returned %+v, expected %+v", got, want)
+	}
+}
+
+func TestEmailBuilder_Build_copy(t *testing.T) {
+	b := NewEmailBuilder().
+		From("ches@example.com", "Ches").
+		To("john@example.com", "").
+		Cc("info@example.com", "").
+		Bcc("dontreply@example.com", "").
+		Attach(EmailAttachment{Content: "aGVsbG8=", Filename: "hello.txt"}).
+		Header("X-Message-Source", "mail.example.com").
+		CustomVar("user_id", "1").
+		Subject("Hello").
+		Text("Hello, world!")
+	got, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	want, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+
+	b.To("mike@example.com", "").
+		Cc("sales@example.com", "").
+		Bcc("audit@example.com", "").
+		Attach(EmailAttachment{Content: "Ynll", Filename: "bye.txt"}).
+		Header("X-Message-Source", "other.example.com").
+		CustomVar("user_id", "2")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Build returned %+v, which changed to %+v after further builder calls", want, got)
+	}
+}
+
+func TestEmailBuilder_invalid(t *testing.T) {
+	got, err := NewEmailBuilder().
+		From("ches@example.com", "Ches").
+		To("john@example.com", "").
+		HTML("<p>Hi</p>").
+		Build()
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "subject" {
+		t.Errorf("Build returned error %v, want the 'subject' validation error", err)
+	}
+	if got != nil {
+		t.Errorf("Build returned %+v, want nil", got)
+	}
+}
@@ -1,10 +1,104 @@
 package mailtrap
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 )
 
+// ErrUnauthorized is matched by errors.Is for responses with the 401 status code,
+// e.g. when the API key is invalid.
+var ErrUnauthorized = errors.New("mailtrap: unauthorized")
+
+// ErrNotFound is matched by errors.Is for responses with the 404 status code
+// and is returned by lookups that find no matching resource.
+var ErrNotFound = errors.New("mailtrap: not found")
+
+// ErrUnverifiedSenderDomain is returned when the from address does not belong
+// to any of the domains configured with WithVerifiedDomains.
+var ErrUnverifiedSenderDomain = errors.New("'from' address domain is not a verified sender domain")
+
+// IsUnverifiedSenderDomainError reports whether the error is caused by an unverified sender domain.
+func IsUnverifiedSenderDomainError(err error) bool {
+	return errors.Is(err, ErrUnverifiedSenderDomain)
+}
+
+// ClientError is returned by Do when the request could not be completed,
+// e.g. because of a DNS failure, a timeout or a TLS error. The underlying
+// error, usually a *url.Error, is available through errors.As.
+type ClientError struct {
+	Op  string
+	URL string
+	Err error
+}
+
+// Error returns the operation and the URL followed by the cause. A *url.Error from the
+// HTTP client repeats the method and the URL, so only its underlying cause is printed.
+func (e *ClientError) Error() string {
+	var urlErr *url.Error
+	if errors.As(e.Err, &urlErr) {
+		return fmt.Sprintf("%s %s: %v", e.Op, e.URL, urlErr.Err)
+	}
+	return fmt.Sprintf("%s %s: %v", e.Op, e.URL, e.Err)
+}
+
+func (e *ClientError) Unwrap() error {
+	return e.Err
+}
+
+// TLSError is wrapped by ClientError when the TLS certificate of the server could not be verified,
+// e.g. because it is signed by a private CA that is not trusted by the system.
+type TLSError struct {
+	Err error
+
+	certificates []*x509.Certificate
+}
+
+func (e *TLSError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TLSError) Unwrap() error {
+	return e.Err
+}
+
+// CertificateInfo returns the certificate chain presented by the server, leaf first.
+func (e *TLSError) CertificateInfo() []*x509.Certificate {
+	return e.certificates
+}
+
+// IsTLSError reports whether the error is caused by a failed verification of the server TLS certificate.
+func IsTLSError(err error) bool {
+	return asTLSError(err) != nil
+}
+
+// asTLSError returns a TLSError for the error if it is caused by a failed certificate verification, or nil otherwise.
+func asTLSError(err error) *TLSError {
+	var te *TLSError
+	if errors.As(err, &te) {
+		return te
+	}
+	var cve *tls.CertificateVerificationError
+	if errors.As(err, &cve) {
+		return &TLSError{Err: err, certificates: cve.UnverifiedCertificates}
+	}
+	var uae x509.UnknownAuthorityError
+	if errors.As(err, &uae) {
+		te = &TLSError{Err: err}
+		if uae.Cert != nil {
+			te.certificates = []*x509.Certificate{uae.Cert}
+		}
+		return te
+	}
+
+	return nil
+}
+
 type ErrorResponse struct {
 	Response *http.Response
 
@@ -16,3 +110,100 @@ func (r *ErrorResponse) Error() string {
 	return fmt.Sprintf("%v %v: %d %v %v",
 		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.Message, r.Errors)
 }
+
+// Is reports whether the error response matches the target sentinel error by its status code.
+func (r *ErrorResponse) Is(target error) bool {
+	if r.Response == nil {
+		return false
+	}
+	switch target {
+	case ErrUnauthorized:
+		return r.Response.StatusCode == http.StatusUnauthorized
+	case ErrNotFound:
+		return r.Response.StatusCode == http.StatusNotFound
+	}
+
+	return false
+}
+
+// QuotaExceededError is returned when the daily sending quota of the account is exceeded.
+type QuotaExceededError struct {
+	Response *http.Response
+
+	Limit   int       `json:"limit"`
+	Used    int       `json:"used"`
+	ResetAt time.Time `json:"reset_at"`
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("daily sending quota exceeded: %d of %d emails used, resets at %v",
+		e.Used, e.Limit, e.ResetAt.Format(time.RFC3339))
+}
+
+// IsQuotaExceeded reports whether the error is caused by exceeding the daily sending quota.
+func IsQuotaExceeded(err error) bool {
+	var qe *QuotaExceededError
+	return errors.As(err, &qe)
+}
+
+// quotaExceededMessage is the message Mailtrap uses to report an exceeded sending quota.
+const quotaExceededMessage = "quota exceeded"
+
+// isQuotaExceededResponse reports whether the error response describes an exceeded sending quota.
+func isQuotaExceededResponse(r *ErrorResponse) bool {
+	if strings.Contains(strings.ToLower(r.Message), quotaExceededMessage) {
+		return true
+	}
+	for _, e := range r.Errors {
+		if strings.Contains(strings.ToLower(e), quotaExceededMessage) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidationError describes an invalid field of a request that was rejected before it was sent.
+type ValidationError struct {
+	// Path of the invalid field, e.g. "from.email" or "attachments[1].filename".
+	Field   string
+	Message string
+
+	// Sentinel error the failure matches with errors.Is, e.g. ErrUnverifiedSenderDomain.
+	err error
+}
+
+func (e ValidationError) Error() string {
+	return e.Message
+}
+
+func (e ValidationError) Unwrap() error {
+	return e.err
+}
+
+// ValidationErrors is returned when a request fails validation. It lists every invalid field
+// and can be extracted with errors.As.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, v := range e {
+		msgs[i] = v.Message
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the individual errors, so that errors.As can also match a single ValidationError.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, v := range e {
+		errs[i] = v
+	}
+
+	return errs
+}
+
+func (e *ValidationErrors) add(field, message string) {
+	*e = append(*e, ValidationError{Field: field, Message: message})
+}
@@ -0,0 +1,169 @@
+package mailtrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuotaExceededError(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{
+			"errors": ["Daily sending quota exceeded"],
+			"limit": 1000,
+			"used": 1000,
+			"reset_at": "2023-02-15T00:00:00Z"
+		}`))
+	})
+
+	req, _ := client.NewRequest(context.Background(), http.MethodGet, "/", nil)
+	_, err := client.Do(context.Background(), req, nil)
+	if !IsQuotaExceeded(err) {
+		t.Fatalf("IsQuotaExceeded(%v) = false, want true", err)
+	}
+
+	var qe *QuotaExceededError
+	if !errors.As(err, &qe) {
+		t.Fatalf("errors.As(%T) = false, want true", err)
+	}
+	if qe.Limit != 1000 || qe.Used != 1000 {
+		t.Errorf("QuotaExceededError Limit=%d Used=%d, want 1000 and 1000", qe.Limit, qe.Used)
+	}
+	if want := time.Date(2023, 2, 15, 0, 0, 0, 0, time.UTC); !qe.ResetAt.Equal(want) {
+		t.Errorf("QuotaExceededError ResetAt=%v, want %v", qe.ResetAt, want)
+	}
+	if qe.Error() == "" {
+		t.Error("QuotaExceededError.Error() is empty")
+	}
+}
+
+func TestQuotaExceededError_otherErrors(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"errors": ["Access forbidden"]}`))
+	})
+
+	req, _ := client.NewRequest(context.Background(), http.MethodGet, "/", nil)
+	_, err := client.Do(context.Background(), req, nil)
+	if err == nil {
+		t.Fatal("Expected HTTP 403 error, got no error.")
+	}
+	if IsQuotaExceeded(err) {
+		t.Errorf("IsQuotaExceeded(%v) = true, want false", err)
+	}
+	if IsQuotaExceeded(nil) {
+		t.Error("IsQuotaExceeded(nil) = true, want false")
+	}
+}
+
+func TestErrorResponse_Is(t *testing.T) {
+	tests := map[int]error{
+		http.StatusUnauthorized: ErrUnauthorized,
+		http.StatusNotFound:     ErrNotFound,
+	}
+	for code, target := range tests {
+		err := &ErrorResponse{Response: &http.Response{StatusCode: code}}
+		if !errors.Is(err, target) {
+			t.Errorf("errors.Is(%d, %v) = false, want true", code, target)
+		}
+	}
+
+	err := &ErrorResponse{Response: &http.Response{StatusCode: http.StatusBadRequest}}
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrUnauthorized) {
+		t.Errorf("errors.Is(%d) matched a sentinel error, want no match", http.StatusBadRequest)
+	}
+}
+
+func TestClientError_Unwrap(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client, err := NewTestingClient("api-token", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewTestingClient returned error: %v", err)
+	}
+	req, _ := client.NewRequest(context.Background(), http.MethodGet, "/accounts", nil)
+	_, err = client.Do(context.Background(), req, nil)
+
+	var clientErr *ClientError
+	if !errors.As(err, &clientErr) {
+		t.Fatalf("Do returned error %v, want a ClientError", err)
+	}
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Errorf("errors.As(%v) did not reach the URL error", err)
+	}
+	var netErr net.Error
+	if !errors.As(err, &netErr) {
+		t.Errorf("errors.As(%v) did not reach the network error", err)
+	}
+	if got := strings.Count(err.Error(), server.URL+"/accounts"); got != 1 {
+		t.Errorf("Error returned %q, want the URL exactly once", err.Error())
+	}
+}
+
+func TestClientError_Error(t *testing.T) {
+	dnsErr := &net.DNSError{Err: "no such host", Name: "send.api.mailtrap.io", IsNotFound: true}
+	err := &ClientError{
+		Op:  "POST",
+		URL: "https://send.api.mailtrap.io/api/send",
+		Err: &url.Error{Op: "Post", URL: "https://send.api.mailtrap.io/api/send", Err: dnsErr},
+	}
+	if got, want := err.Error(), "POST https://send.api.mailtrap.io/api/send: lookup send.api.mailtrap.io: no such host"; got != want {
+		t.Errorf("Error returned %q, expected %q", got, want)
+	}
+
+	err = &ClientError{Op: "GET", URL: "/", Err: errors.New("boom")}
+	if got, want := err.Error(), "GET /: boom"; got != want {
+		t.Errorf("Error returned %q, expected %q", got, want)
+	}
+}
+
+func TestIsTLSError(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client, err := NewTestingClient("api-token", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewTestingClient returned error: %v", err)
+	}
+
+	_, _, err = client.Accounts.List()
+	if !IsTLSError(err) {
+		t.Fatalf("IsTLSError(%v) = false, want true", err)
+	}
+
+	var te *TLSError
+	if !errors.As(err, &te) {
+		t.Fatalf("errors.As(%v) did not reach the TLS error", err)
+	}
+	certs := te.CertificateInfo()
+	if len(certs) == 0 || !certs[0].Equal(server.Certificate()) {
+		t.Errorf("TLSError.CertificateInfo returned %v, expected the server certificate", certs)
+	}
+	var uerr *url.Error
+	if !errors.As(err, &uerr) {
+		t.Errorf("errors.As(%v) did not reach the URL error", err)
+	}
+
+	if IsTLSError(errors.New("connection refused")) {
+		t.Error("IsTLSError(connection refused) = true, want false")
+	}
+}
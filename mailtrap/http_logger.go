@@ -0,0 +1,165 @@
+package mailtrap
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HTTPLogLevel controls how much of the HTTP traffic WithHTTPLogger writes.
+type HTTPLogLevel int
+
+const (
+	// LogLevelBasic logs the method, URL and response status of each request.
+	LogLevelBasic HTTPLogLevel = iota
+	// LogLevelHeaders additionally logs the request and response headers.
+	LogLevelHeaders
+	// LogLevelFull additionally logs the request and response bodies.
+	LogLevelFull
+)
+
+// loggingTransport is an http.RoundTripper that writes the HTTP traffic to w.
+type loggingTransport struct {
+	next  http.RoundTripper
+	level HTTPLogLevel
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// RoundTrip logs the request, executes it with the wrapped transport and logs the response.
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "--> %s %s\n", req.Method, req.URL)
+	if t.level >= LogLevelHeaders {
+		writeHeaders(&buf, req.Header)
+	}
+	if t.level >= LogLevelFull && req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			body.Close()
+			writeBody(&buf, data)
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		fmt.Fprintf(&buf, "<-- %s %s error: %v (%s)\n", req.Method, req.URL, err, elapsed)
+		t.write(buf.Bytes())
+		return nil, err
+	}
+
+	fmt.Fprintf(&buf, "<-- %s %s %s (%s)\n", resp.Status, req.Method, req.URL, elapsed)
+	if t.level >= LogLevelHeaders {
+		writeHeaders(&buf, resp.Header)
+	}
+	if t.level >= LogLevelFull && resp.Body != nil {
+		data, rerr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		if rerr == nil {
+			writeBody(&buf, data)
+		}
+	}
+	t.write(buf.Bytes())
+
+	return resp, nil
+}
+
+func (t *loggingTransport) write(p []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = t.w.Write(p)
+}
+
+// writeHeaders writes the headers sorted by name, masking the Authorization header value.
+func writeHeaders(buf *bytes.Buffer, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, v := range header[name] {
+			if strings.EqualFold(name, "Authorization") {
+				v = maskAuthorization(v)
+			}
+			fmt.Fprintf(buf, "%s: %s\n", name, v)
+		}
+	}
+}
+
+func writeBody(buf *bytes.Buffer, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	buf.Write(body)
+	if body[len(body)-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+}
+
+// maskAuthorization masks the credentials of an Authorization header value with MaskAPIKey, keeping the scheme.
+func maskAuthorization(v string) string {
+	if scheme, credentials, ok := strings.Cut(v, " "); ok {
+		return scheme + " " + MaskAPIKey(credentials)
+	}
+
+	return MaskAPIKey(v)
+}
+
+// debugTransport is an http.RoundTripper that writes dumps of the requests and responses to w.
+type debugTransport struct {
+	next http.RoundTripper
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// authorizationHeaderLine matches the Authorization header line of a request dump,
+// capturing the header name and the scheme.
+var authorizationHeaderLine = regexp.MustCompile(`(?im)^(Authorization:[ \t]*)(\S+[ \t]+)?[^\r\n]*`)
+
+// RoundTrip dumps the request, executes it with the wrapped transport and dumps the response.
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var buf bytes.Buffer
+	if dump, err := httputil.DumpRequestOut(req, true); err == nil {
+		buf.Write(authorizationHeaderLine.ReplaceAll(dump, []byte("${1}${2}[REDACTED]")))
+	} else {
+		fmt.Fprintf(&buf, "%s %s: dump request: %v\n", req.Method, req.URL, err)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		fmt.Fprintf(&buf, "\n%s %s error: %v\n", req.Method, req.URL, err)
+		t.write(buf.Bytes())
+		return nil, err
+	}
+
+	buf.WriteString("\n")
+	if dump, derr := httputil.DumpResponse(resp, true); derr == nil {
+		buf.Write(dump)
+	} else {
+		fmt.Fprintf(&buf, "%s %s: dump response: %v\n", req.Method, req.URL, derr)
+	}
+	buf.WriteString("\n")
+	t.write(buf.Bytes())
+
+	return resp, nil
+}
+
+func (t *debugTransport) write(p []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = t.w.Write(p)
+}
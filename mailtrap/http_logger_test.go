@@ -0,0 +1,130 @@
+package mailtrap
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithHTTPLogger(t *testing.T) {
+	const apiKey = "secret-api-key"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		fmt.Fprint(w, `{"id":1}`)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		level   HTTPLogLevel
+		want    []string
+		notWant []string
+	}{
+		{
+			level:   LogLevelBasic,
+			want:    []string{"--> POST " + server.URL + "/projects", "<-- 200 OK POST " + server.URL + "/projects"},
+			notWant: []string{"Authorization", "X-Request-Id", `"name":"p1"`, `{"id":1}`},
+		},
+		{
+			level:   LogLevelHeaders,
+			want:    []string{"Authorization: Bearer **********-key", "X-Request-Id: req-1"},
+			notWant: []string{`"name":"p1"`, `{"id":1}`},
+		},
+		{
+			level: LogLevelFull,
+			want:  []string{"Authorization: Bearer **********-key", `{"name":"p1"}`, `{"id":1}`},
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		client, err := NewTestingClient(apiKey,
+			WithBaseURL(server.URL), WithHTTPLogger(&buf), WithHTTPLogLevel(tt.level))
+		if err != nil {
+			t.Fatalf("NewTestingClient returned error: %v", err)
+		}
+
+		req, _ := client.NewRequest(context.Background(), http.MethodPost, "/projects", map[string]string{"name": "p1"})
+		var got map[string]int
+		if _, err := client.Do(context.Background(), req, &got); err != nil {
+			t.Fatalf("Do returned error: %v", err)
+		}
+		if got["id"] != 1 {
+			t.Errorf("Do decoded %v, want the logged response body to be readable", got)
+		}
+
+		log := buf.String()
+		for _, s := range tt.want {
+			if !strings.Contains(log, s) {
+				t.Errorf("log at level %d = %q, want it to contain %q", tt.level, log, s)
+			}
+		}
+		for _, s := range append(tt.notWant, apiKey) {
+			if strings.Contains(log, s) {
+				t.Errorf("log at level %d = %q, must not contain %q", tt.level, log, s)
+			}
+		}
+	}
+
+	if http.DefaultClient.Transport != nil {
+		t.Error("WithHTTPLogger modified http.DefaultClient")
+	}
+	if _, err := NewTestingClient(apiKey, WithHTTPLogLevel(HTTPLogLevel(5))); err == nil {
+		t.Error("NewTestingClient with unknown log level err = nil, want error")
+	}
+}
+
+func TestWithDebug(t *testing.T) {
+	const apiKey = "secret-api-key"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer "+apiKey {
+			t.Errorf("server received Authorization %q, want the real API key", got)
+		}
+		w.Header().Set("X-Request-Id", "req-1")
+		fmt.Fprint(w, `{"id":1}`)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client, err := NewTestingClient(apiKey, WithBaseURL(server.URL), WithDebug(&buf))
+	if err != nil {
+		t.Fatalf("NewTestingClient returned error: %v", err)
+	}
+
+	req, _ := client.NewRequest(context.Background(), http.MethodPost, "/projects", map[string]string{"name": "p1"})
+	var got map[string]int
+	if _, err := client.Do(context.Background(), req, &got); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if got["id"] != 1 {
+		t.Errorf("Do decoded %v, want the dumped response body to be readable", got)
+	}
+
+	dump := buf.String()
+	for _, s := range []string{"POST /projects HTTP/1.1", "Authorization: Bearer [REDACTED]", `{"name":"p1"}`,
+		"HTTP/1.1 200 OK", "X-Request-Id: req-1", `{"id":1}`} {
+		if !strings.Contains(dump, s) {
+			t.Errorf("debug output %q does not contain %q", dump, s)
+		}
+	}
+	if strings.Contains(dump, apiKey) {
+		t.Errorf("debug output %q contains the API key", dump)
+	}
+}
+
+func TestAuthorizationHeaderLine(t *testing.T) {
+	tests := map[string]string{
+		"Authorization: Bearer abc\r\nAccept: */*\r\n": "Authorization: Bearer [REDACTED]\r\nAccept: */*\r\n",
+		"Authorization: abc\r\n":                       "Authorization: [REDACTED]\r\n",
+		"X-Authorization-Id: abc\r\n":                  "X-Authorization-Id: abc\r\n",
+	}
+	for in, want := range tests {
+		if got := authorizationHeaderLine.ReplaceAllString(in, "${1}${2}[REDACTED]"); got != want {
+			t.Errorf("redacted %q = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package mailtrap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lint returns advisory warnings about the request. Unlike validation errors,
+// warnings do not prevent the request from being sent.
+func (r *SendEmailRequest) Lint() []string {
+	var warnings []string
+
+	warnings = append(warnings, r.lintSameDomainRecipients()...)
+	warnings = append(warnings, r.lintFromDomainTypo()...)
+	warnings = append(warnings, r.lintFreeEmailDomain()...)
+	warnings = append(warnings, r.lintSize()...)
+	warnings = append(warnings, r.lintBodyAlternatives()...)
+
+	return warnings
+}
+
+// lintSameDomainRecipients warns about recipients on the sender's domain, which may cause mail loops.
+func (r *SendEmailRequest) lintSameDomainRecipients() []string {
+	var warnings []string
+	for _, field := range r.sameDomainRecipients() {
+		warnings = append(warnings, fmt.Sprintf("'%s' address has the same domain as 'from' address, which may cause mail loops", field))
+	}
+
+	return warnings
+}
+
+// sameDomainRecipients returns the recipient fields whose domain matches the domain of the from address.
+func (r *SendEmailRequest) sameDomainRecipients() []string {
+	fromDomain := emailDomain(r.From.Email)
+	if fromDomain == "" {
+		return nil
+	}
+
+	recipients := []struct {
+		name  string
+		addrs []EmailAddress
+	}{{"to", r.To}, {"cc", r.Cc}, {"bcc", r.Bcc}}
+
+	var fields []string
+	for _, rcpt := range recipients {
+		for i, a := range rcpt.addrs {
+			if emailDomain(a.Email) == fromDomain {
+				fields = append(fields, fmt.Sprintf("%s[%d]", rcpt.name, i))
+			}
+		}
+	}
+
+	return fields
+}
+
+// commonDomainTypos maps common misspellings of popular email domains to the intended domain.
+var commonDomainTypos = map[string]string{
+	"example.con": "example.com",
+	"gmail.con":   "gmail.com",
+	"gmail.co":    "gmail.com",
+	"gmial.com":   "gmail.com",
+	"gmai.com":    "gmail.com",
+	"gamil.com":   "gmail.com",
+	"gnail.com":   "gmail.com",
+	"yahoo.con":   "yahoo.com",
+	"yaho.com":    "yahoo.com",
+	"yahooo.com":  "yahoo.com",
+	"hotmail.con": "hotmail.com",
+	"hotmial.com": "hotmail.com",
+	"hotmal.com":  "hotmail.com",
+	"outlook.con": "outlook.com",
+	"outlok.com":  "outlook.com",
+	"icloud.con":  "icloud.com",
+	"iclod.com":   "icloud.com",
+}
+
+// lintFromDomainTypo warns if the from address domain looks like a misspelling of a popular domain.
+func (r *SendEmailRequest) lintFromDomainTypo() []string {
+	domain := emailDomain(r.From.Email)
+	if want, ok := commonDomainTypos[domain]; ok {
+		return []string{fmt.Sprintf("'from' address domain %q looks like a misspelling of %q", domain, want)}
+	}
+
+	return nil
+}
+
+// FreeEmailDomains lists free email provider domains that cannot be verified as sender domains,
+// so sending from them through the production API fails. Lint warns when the from address uses one.
+// The list may be extended by callers.
+var FreeEmailDomains = []string{
+	"gmail.com",
+	"googlemail.com",
+	"yahoo.com",
+	"hotmail.com",
+	"outlook.com",
+	"live.com",
+	"aol.com",
+	"icloud.com",
+	"mail.com",
+	"gmx.com",
+	"proton.me",
+	"protonmail.com",
+	"yandex.com",
+	"zoho.com",
+}
+
+// lintFreeEmailDomain warns if the from address uses a free email provider domain.
+func (r *SendEmailRequest) lintFreeEmailDomain() []string {
+	domain := emailDomain(r.From.Email)
+	if domain == "" {
+		return nil
+	}
+	for _, d := range FreeEmailDomains {
+		if strings.EqualFold(d, domain) {
+			return []string{fmt.Sprintf("'from' address domain %q is a free email provider and cannot be a verified sender domain", domain)}
+		}
+	}
+
+	return nil
+}
+
+// maxEstimatedSize is the estimated email size above which delivery may fail at the SMTP level.
+const maxEstimatedSize int64 = 10 << 20
+
+// lintSize warns when the estimated email size exceeds maxEstimatedSize.
+func (r *SendEmailRequest) lintSize() []string {
+	if size := r.EstimateSize(); size > maxEstimatedSize {
+		return []string{fmt.Sprintf("estimated email size of %d bytes exceeds %d bytes and may be rejected", size, maxEstimatedSize)}
+	}
+
+	return nil
+}
+
+// lintBodyAlternatives warns when only one of the HTML and text bodies is set.
+func (r *SendEmailRequest) lintBodyAlternatives() []string {
+	switch {
+	case r.HTML != "" && r.Text == "":
+		return []string{"'html' is set without a 'text' fallback for clients that do not render HTML; " +
+			"set 'text', e.g. with StripHTMLForText, or enable WithAutoTextFallback()"}
+	case r.Text != "" && r.HTML == "":
+		return []string{"'text' is set without an 'html' version; " +
+			"set 'html' or enable WithAutoHTMLFromText()"}
+	}
+
+	return nil
+}
+
+// emailDomain returns the lowercased domain part of the email address.
+func emailDomain(addr string) string {
+	at := strings.LastIndex(addr, "@")
+	if at < 0 {
+		return ""
+	}
+
+	return strings.ToLower(addr[at+1:])
+}
@@ -0,0 +1,184 @@
+package mailtrap
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSendEmailRequest_Lint_sameDomain(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *SendEmailRequest
+		want []string
+	}{
+		{
+			name: "exact address",
+			req: &SendEmailRequest{
+				From: EmailAddress{Email: "ches@example.com"},
+				To:   []EmailAddress{{Email: "ches@example.com"}},
+			},
+			want: []string{"'to[0]' address has the same domain as 'from' address, which may cause mail loops"},
+		},
+		{
+			name: "same domain, different user",
+			req: &SendEmailRequest{
+				From: EmailAddress{Email: "ches@example.com"},
+				To:   []EmailAddress{{Email: "john@other.com"}},
+				Cc:   []EmailAddress{{Email: "info@other.com"}, {Email: "info@EXAMPLE.com"}},
+				Bcc:  []EmailAddress{{Email: "audit@example.com"}},
+			},
+			want: []string{
+				"'cc[1]' address has the same domain as 'from' address, which may cause mail loops",
+				"'bcc[0]' address has the same domain as 'from' address, which may cause mail loops",
+			},
+		},
+		{
+			name: "cross domain",
+			req: &SendEmailRequest{
+				From: EmailAddress{Email: "ches@example.com"},
+				To:   []EmailAddress{{Email: "john@other.com"}},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.req.Lint(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendEmailService_Send_strictValidation(t *testing.T) {
+	client, mux, teardown := setupSendingClient(WithStrictValidation())
+	defer teardown()
+
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true,"message_ids":["1"]}`)
+	})
+
+	email := &SendEmailRequest{
+		From:    EmailAddress{Email: "ches@example.com"},
+		To:      []EmailAddress{{Email: "john@other.com"}, {Email: "mike@example.com"}},
+		Subject: "Subj.",
+		Text:    "Test",
+	}
+	_, _, err := client.Send(context.Background(), email)
+	if err == nil || err.Error() != "'to[1]' address has the same domain as 'from' address" {
+		t.Errorf("SendEmail.Send returned error: %v", err)
+	}
+
+	email.To = email.To[:1]
+	if _, _, err := client.Send(context.Background(), email); err != nil {
+		t.Errorf("SendEmail.Send returned error: %v", err)
+	}
+
+	lenient, mux, teardown := setupSendingClient()
+	defer teardown()
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true,"message_ids":["1"]}`)
+	})
+
+	email.To = append(email.To, EmailAddress{Email: "mike@example.com"})
+	if _, _, err := lenient.Send(context.Background(), email); err != nil {
+		t.Errorf("SendEmail.Send without strict validation returned error: %v", err)
+	}
+}
+
+func TestSendEmailRequest_Lint_fromDomainTypo(t *testing.T) {
+	req := &SendEmailRequest{From: EmailAddress{Email: "user@gmail.con"}}
+	want := []string{`'from' address domain "gmail.con" looks like a misspelling of "gmail.com"`}
+	if got := req.Lint(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint() = %q, want %q", got, want)
+	}
+
+	req = &SendEmailRequest{From: EmailAddress{Email: "user@example.com"}}
+	if got := req.Lint(); got != nil {
+		t.Errorf("Lint() = %q, want nil", got)
+	}
+}
+
+func TestSendEmailRequest_Lint_freeEmailDomain(t *testing.T) {
+	req := &SendEmailRequest{From: EmailAddress{Email: "user@Gmail.com"}}
+	want := []string{`'from' address domain "gmail.com" is a free email provider and cannot be a verified sender domain`}
+	if got := req.Lint(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint() = %q, want %q", got, want)
+	}
+
+	req = &SendEmailRequest{From: EmailAddress{Email: "user@freemail.test"}}
+	if got := req.Lint(); got != nil {
+		t.Errorf("Lint() = %q, want nil", got)
+	}
+
+	defer func(domains []string) { FreeEmailDomains = domains }(FreeEmailDomains)
+	FreeEmailDomains = append(FreeEmailDomains[:len(FreeEmailDomains):len(FreeEmailDomains)], "freemail.test")
+	if got := req.Lint(); len(got) != 1 || !strings.Contains(got[0], "free email provider") {
+		t.Errorf("Lint() = %q, want free email provider warning", got)
+	}
+}
+
+func TestSendEmailRequest_EstimateSize(t *testing.T) {
+	req := &SendEmailRequest{
+		Subject: strings.Repeat("s", 10),
+		Text:    strings.Repeat("t", 100),
+		HTML:    strings.Repeat("h", 200),
+		Headers: map[HeaderName]string{"X-Key": "value"},
+		Attachments: []EmailAttachment{
+			{Content: base64.StdEncoding.EncodeToString(make([]byte, 300))},
+		},
+	}
+
+	// 10 + 100 + 200 + len("X-Key") + len("value") + 300 * 4/3
+	var want int64 = 10 + 100 + 200 + 5 + 5 + 400
+	if got := req.EstimateSize(); got != want {
+		t.Errorf("EstimateSize() = %d, want %d", got, want)
+	}
+}
+
+func TestSendEmailRequest_Lint_size(t *testing.T) {
+	req := &SendEmailRequest{
+		From: EmailAddress{Email: "ches@example.com"},
+		HTML: strings.Repeat("h", 11<<20),
+		Text: "small",
+	}
+
+	warnings := req.Lint()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "estimated email size") {
+		t.Errorf("Lint() = %q, want estimated size warning", warnings)
+	}
+
+	req.HTML = "<p>small</p>"
+	if warnings := req.Lint(); warnings != nil {
+		t.Errorf("Lint() = %q, want nil", warnings)
+	}
+}
+
+func TestSendEmailRequest_Lint_bodyAlternatives(t *testing.T) {
+	tests := []struct {
+		name, html, text string
+		want             string
+	}{
+		{name: "html only", html: "<p>Hi</p>", want: "WithAutoTextFallback()"},
+		{name: "text only", text: "Hi", want: "WithAutoHTMLFromText()"},
+		{name: "both", html: "<p>Hi</p>", text: "Hi"},
+	}
+	for _, tt := range tests {
+		req := &SendEmailRequest{From: EmailAddress{Email: "ches@example.com"}, HTML: tt.html, Text: tt.text}
+		warnings := req.Lint()
+		if tt.want == "" {
+			if warnings != nil {
+				t.Errorf("Lint() for %s = %q, want nil", tt.name, warnings)
+			}
+			continue
+		}
+		if len(warnings) != 1 || !strings.Contains(warnings[0], tt.want) {
+			t.Errorf("Lint() for %s = %q, want a warning mentioning %s", tt.name, warnings, tt.want)
+		}
+	}
+}
@@ -2,14 +2,22 @@ package mailtrap
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"regexp"
 	"runtime"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -40,16 +48,71 @@ type client struct {
 
 	// HTTP client used to communicate with the API.
 	httpClient *http.Client
+
+	// Transport replacing the one of the HTTP client. Nil keeps the transport of the HTTP client.
+	transport http.RoundTripper
+
+	// Timeout replacing the one of the HTTP client. Zero keeps the timeout of the HTTP client.
+	timeout time.Duration
+
+	// Retries of requests failed with transient errors. Nil disables retries.
+	retry *RetryConfig
+
+	// Generate the HTML body from the text body before sending.
+	autoHTMLFromText bool
+
+	// Generate the text body from the HTML body before sending.
+	autoTextFallback bool
+
+	// Treat selected lint warnings as validation errors.
+	strictValidation bool
+
+	// Sender domains the from address must belong to. Empty allows all domains.
+	verifiedDomains []string
+
+	// Verify the API key and connectivity when the client is constructed.
+	eagerVerify bool
+
+	// Only allow custom X- header names in requests.
+	safeHeaders bool
+
+	// Functions applied to every request before it is returned by NewRequest, in registration order.
+	requestPreProcessors []func(*http.Request) error
+
+	// Semaphore limiting the number of in-flight requests. Nil means unlimited.
+	requestSem chan struct{}
+
+	// Token bucket limiting the rate of requests. Nil means unlimited.
+	rateLimiter *rate.Limiter
+
+	// Maximum duration of a single Do call, including reading the response. Zero means no limit.
+	operationTimeout time.Duration
+
+	// Destination and verbosity of the HTTP traffic log. Nil disables logging.
+	httpLogWriter io.Writer
+	httpLogLevel  HTTPLogLevel
+
+	// Destination of the raw HTTP request and response dumps. Nil disables them.
+	debugWriter io.Writer
+
+	// Log of the raw bodies of error responses. Nil disables logging.
+	errorBodyLog *errorBodyLogger
+
+	// Log of the successfully sent emails. Nil disables the audit log.
+	auditLog *auditLogger
 }
 
 // SendingClient is an interface for managing communication with the Mailtrap send and sandbox APIs.
+// The clients are safe for concurrent use by multiple goroutines.
 type SendingClient interface {
-	Send(request *SendEmailRequest) (*SendEmailResponse, *Response, error)
-	NewRequest(method, path string, body interface{}) (*http.Request, error)
-	Do(req *http.Request, v interface{}) (*Response, error)
-
-	// setBaseURL sets the base URL for the API client and is used by internal tests.
-	setBaseURL(url.URL)
+	Send(ctx context.Context, request *SendEmailRequest) (*SendEmailResponse, *Response, error)
+	NewRequest(
+		ctx context.Context,
+		method, path string,
+		body interface{},
+		opts ...RequestOption,
+	) (*http.Request, error)
+	Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error)
 }
 
 // TestingClient manages communication with the Mailtrap testing API.
@@ -64,11 +127,12 @@ type TestingClient struct {
 	Inboxes      *InboxesService
 	Messages     *MessagesService
 	Attachments  *AttachmentsService
+	Templates    *TemplatesService
 }
 
 // NewSendingClient creates and returns a production instance of SendingClient.
-func NewSendingClient(apiKey string) (SendingClient, error) {
-	client, err := getClient(apiKey, sendingAPIURL)
+func NewSendingClient(apiKey string, opts ...Option) (SendingClient, error) {
+	client, err := getClient(apiKey, sendingAPIURL, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -81,8 +145,8 @@ func NewSendingClient(apiKey string) (SendingClient, error) {
 }
 
 // NewSendingClient creates and returns a sandbox instance of SendingClient for development and testing.
-func NewSandboxSendingClient(apiKey string, inboxID int64) (SendingClient, error) {
-	client, err := getClient(apiKey, sandboxAPIURL)
+func NewSandboxSendingClient(apiKey string, inboxID int64, opts ...Option) (SendingClient, error) {
+	client, err := getClient(apiKey, sandboxAPIURL, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -95,26 +159,183 @@ func NewSandboxSendingClient(apiKey string, inboxID int64) (SendingClient, error
 	return sc, nil
 }
 
+// Sending modes accepted by NewAutoSendingClient.
+const (
+	SendingModeProduction = "production"
+	SendingModeSandbox    = "sandbox"
+	SendingModeAuto       = "auto"
+)
+
+// NewAutoSendingClient creates a production or sandbox SendingClient depending on the mode.
+// In SendingModeAuto the MAILTRAP_ENV environment variable decides: "test" selects the sandbox
+// client delivering to sandboxInboxID, any other value selects the production client.
+func NewAutoSendingClient(apiKey string, sandboxInboxID int64, mode string, opts ...Option) (SendingClient, error) {
+	if mode == SendingModeAuto {
+		mode = SendingModeProduction
+		if os.Getenv("MAILTRAP_ENV") == "test" {
+			mode = SendingModeSandbox
+		}
+	}
+
+	switch mode {
+	case SendingModeProduction:
+		return NewSendingClient(apiKey, opts...)
+	case SendingModeSandbox:
+		return NewSandboxSendingClient(apiKey, sandboxInboxID, opts...)
+	default:
+		return nil, fmt.Errorf("unknown sending mode %q", mode)
+	}
+}
+
+// Environment variables read by the FromEnv constructors.
+const (
+	envAPIKey  = "MAILTRAP_API_KEY"
+	envBaseURL = "MAILTRAP_BASE_URL"
+	envInboxID = "MAILTRAP_INBOX_ID"
+)
+
+// NewSendingClientFromEnv creates a production SendingClient with the API key from the
+// MAILTRAP_API_KEY environment variable. If MAILTRAP_BASE_URL is set, it is used as the
+// base URL like with WithBaseURL. Options passed explicitly take precedence.
+func NewSendingClientFromEnv(opts ...Option) (SendingClient, error) {
+	apiKey, opts, err := envConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSendingClient(apiKey, opts...)
+}
+
+// NewSandboxSendingClientFromEnv is like NewSendingClientFromEnv but creates a sandbox SendingClient
+// delivering to the inbox with the ID from the MAILTRAP_INBOX_ID environment variable.
+func NewSandboxSendingClientFromEnv(opts ...Option) (SendingClient, error) {
+	apiKey, opts, err := envConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	v := os.Getenv(envInboxID)
+	if v == "" {
+		return nil, fmt.Errorf("environment variable %s is not set", envInboxID)
+	}
+	inboxID, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("environment variable %s is not a valid inbox ID: %q", envInboxID, v)
+	}
+
+	return NewSandboxSendingClient(apiKey, inboxID, opts...)
+}
+
+// envConfig returns the API key from the environment and the options with the base URL
+// from the environment, if set, prepended.
+func envConfig(opts []Option) (string, []Option, error) {
+	apiKey := os.Getenv(envAPIKey)
+	if apiKey == "" {
+		return "", nil, fmt.Errorf("environment variable %s is not set", envAPIKey)
+	}
+	if baseURL := os.Getenv(envBaseURL); baseURL != "" {
+		opts = append([]Option{WithBaseURL(baseURL)}, opts...)
+	}
+
+	return apiKey, opts, nil
+}
+
 // getClient returns a new client instance with the given API key and base URL.
-func getClient(apiKey string, baseURL string) (client, error) {
+func getClient(apiKey string, baseURL string, opts ...Option) (client, error) {
 	u, err := url.Parse(baseURL)
 	if err != nil {
 		return client{}, err
 	}
 	u.Path += apiSuffix
 
-	return client{
+	c := client{
 		apiKey:  apiKey,
 		baseURL: *u,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 		userAgent: userAgent,
-	}, nil
+	}
+	if err := c.applyOptions(opts); err != nil {
+		return client{}, err
+	}
+
+	return c, nil
+}
+
+// APIKeyLast4 returns the last 4 characters of the API key, or "****" if the key is shorter,
+// to identify the key in logs without exposing it.
+func (c *client) APIKeyLast4() string {
+	if len(c.apiKey) < 4 {
+		return "****"
+	}
+
+	return c.apiKey[len(c.apiKey)-4:]
+}
+
+// MaskAPIKey masks all but the last 4 characters of the API key with asterisks.
+// Keys of 4 characters or less are masked completely.
+func MaskAPIKey(key string) string {
+	if len(key) <= 4 {
+		return strings.Repeat("*", len(key))
+	}
+
+	return strings.Repeat("*", len(key)-4) + key[len(key)-4:]
+}
+
+// applyOptions applies the given options to the client.
+func (c *client) applyOptions(opts []Option) error {
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt(c); err != nil {
+			return err
+		}
+	}
+
+	if c.transport != nil || c.timeout > 0 || c.httpLogWriter != nil || c.debugWriter != nil || c.retry != nil {
+		// Copy the HTTP client so that a shared client such as http.DefaultClient is left untouched.
+		hc := *c.httpClient
+		if c.transport != nil {
+			hc.Transport = c.transport
+		}
+		if c.timeout > 0 {
+			hc.Timeout = c.timeout
+		}
+		if c.httpLogWriter != nil {
+			next := hc.Transport
+			if next == nil {
+				next = http.DefaultTransport
+			}
+			hc.Transport = &loggingTransport{next: next, level: c.httpLogLevel, w: c.httpLogWriter}
+		}
+		if c.debugWriter != nil {
+			next := hc.Transport
+			if next == nil {
+				next = http.DefaultTransport
+			}
+			hc.Transport = &debugTransport{next: next, w: c.debugWriter}
+		}
+		if c.retry != nil {
+			next := hc.Transport
+			if next == nil {
+				next = http.DefaultTransport
+			}
+			hc.Transport = &retryTransport{next: next, cfg: *c.retry}
+		}
+		c.httpClient = &hc
+	}
+
+	return nil
 }
 
 // NewTestingClient creates and returns an instance of TestingClient.
-func NewTestingClient(apiKey string) (*TestingClient, error) {
+func NewTestingClient(apiKey string, opts ...Option) (*TestingClient, error) {
+	return NewTestingClientWithContext(context.Background(), apiKey, opts...)
+}
+
+// NewTestingClientWithContext is like NewTestingClient but uses ctx for the request made by WithEagerVerify.
+func NewTestingClientWithContext(ctx context.Context, apiKey string, opts ...Option) (*TestingClient, error) {
 	baseURL, err := url.Parse(testingAPIURL)
 	if err != nil {
 		return nil, err
@@ -129,6 +350,9 @@ func NewTestingClient(apiKey string) (*TestingClient, error) {
 			userAgent:  userAgent,
 		},
 	}
+	if err := client.applyOptions(opts); err != nil {
+		return nil, err
+	}
 
 	// Create all the public services.
 	client.Accounts = &AccountsService{client: &client.client}
@@ -138,24 +362,89 @@ func NewTestingClient(apiKey string) (*TestingClient, error) {
 	client.Inboxes = &InboxesService{client: &client.client}
 	client.Messages = &MessagesService{client: &client.client}
 	client.Attachments = &AttachmentsService{client: &client.client}
+	client.Templates = &TemplatesService{client: &client.client}
+
+	if client.eagerVerify {
+		if _, _, err := client.Accounts.ListWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("verify connection: %w", err)
+		}
+	}
 
 	return client, nil
 }
 
-func (c *client) Do(req *http.Request, v interface{}) (*Response, error) {
-	resp, err := c.httpClient.Do(req)
+// NewTestingClientFromEnv creates a TestingClient the same way NewSendingClientFromEnv
+// creates a sending client, from the MAILTRAP_API_KEY and MAILTRAP_BASE_URL environment variables.
+func NewTestingClientFromEnv(opts ...Option) (*TestingClient, error) {
+	apiKey, opts, err := envConfig(opts)
 	if err != nil {
 		return nil, err
 	}
 
+	return NewTestingClient(apiKey, opts...)
+}
+
+// Do sends the API request bound to ctx and decodes the response body into v.
+// Canceling ctx or the context the request was created with aborts the request,
+// including waiting for the response.
+func (c *client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	if ctx == nil {
+		return nil, errors.New("context must be non-nil")
+	}
+	if reqCtx := req.Context(); reqCtx.Done() != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		defer context.AfterFunc(reqCtx, cancel)()
+	}
+	if c.operationTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.operationTimeout)
+		defer cancel()
+	}
+	req = req.WithContext(ctx)
+
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(req.Context()); err != nil {
+			if ctxErr := req.Context().Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			return nil, err
+		}
+	}
+
+	if c.requestSem != nil {
+		select {
+		case c.requestSem <- struct{}{}:
+			defer func() { <-c.requestSem }()
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	duration := time.Since(start)
+	if err != nil {
+		if te := asTLSError(err); te != nil {
+			err = te
+		}
+		return nil, &ClientError{Op: req.Method, URL: req.URL.String(), Err: err}
+	}
+
 	defer func() {
 		if rerr := resp.Body.Close(); err == nil {
 			err = rerr
 		}
 	}()
 
-	response := &Response{Response: resp}
-	if err := checkResponse(resp); err != nil {
+	if resp.Request == nil {
+		// A custom transport may return a response without its request.
+		resp.Request = req
+	}
+
+	response := &Response{Response: resp, Duration: duration}
+	if err := checkResponse(req, resp, c.errorBodyLog); err != nil {
 		return response, err
 	}
 
@@ -180,6 +469,14 @@ func (c *client) decode(v interface{}, body io.Reader, acceptHeader string) erro
 		*s = string(data)
 		return nil
 	}
+	if b, ok := v.(*[]byte); ok {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		*b = data
+		return nil
+	}
 	if v != nil && acceptHeader == defaultAccept {
 		if err := json.NewDecoder(body).Decode(v); err != nil {
 			return err
@@ -190,10 +487,30 @@ func (c *client) decode(v interface{}, body io.Reader, acceptHeader string) erro
 	return errors.New("decode() undefined response type")
 }
 
-// NewRequest creates an API request.
-func (c *client) NewRequest(method, path string, body interface{}) (*http.Request, error) {
+// RequestOption configures a single API request.
+type RequestOption func(*http.Request)
+
+// WithRequestUserAgent overrides the User-Agent header for a single request.
+// An empty user agent leaves the client's default in place.
+func WithRequestUserAgent(ua string) RequestOption {
+	return func(req *http.Request) {
+		if ua != "" {
+			req.Header.Set("User-Agent", ua)
+		}
+	}
+}
+
+// NewRequest creates an API request bound to the given context.
+// The request options are applied after the default headers are set.
+func (c *client) NewRequest(
+	ctx context.Context,
+	method, path string,
+	body interface{},
+	opts ...RequestOption,
+) (*http.Request, error) {
+	// baseURL is copied by value, so concurrent requests never modify the client's URL.
 	u := c.baseURL
-	u.Path = c.baseURL.Path + path
+	u.Path += path
 
 	var (
 		req *http.Request
@@ -202,7 +519,7 @@ func (c *client) NewRequest(method, path string, body interface{}) (*http.Reques
 
 	switch method {
 	case http.MethodGet, http.MethodHead, http.MethodOptions:
-		req, err = http.NewRequest(method, u.String(), nil)
+		req, err = http.NewRequestWithContext(ctx, method, u.String(), nil)
 		if err != nil {
 			return nil, err
 		}
@@ -214,7 +531,7 @@ func (c *client) NewRequest(method, path string, body interface{}) (*http.Reques
 				return nil, err
 			}
 		}
-		req, err = http.NewRequest(method, u.String(), buf)
+		req, err = http.NewRequestWithContext(ctx, method, u.String(), buf)
 		if err != nil {
 			return nil, err
 		}
@@ -227,6 +544,18 @@ func (c *client) NewRequest(method, path string, body interface{}) (*http.Reques
 	req.Header.Set("User-Agent", c.userAgent)
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
 
+	for _, opt := range opts {
+		if opt != nil {
+			opt(req)
+		}
+	}
+
+	for _, fn := range c.requestPreProcessors {
+		if err := fn(req); err != nil {
+			return nil, err
+		}
+	}
+
 	return req, nil
 }
 
@@ -234,22 +563,172 @@ func (c *client) NewRequest(method, path string, body interface{}) (*http.Reques
 // This wraps the standard http.Response returned from Mailtrap.
 type Response struct {
 	*http.Response
+
+	// Duration is the round-trip time of the HTTP request, up to the response headers.
+	Duration time.Duration
+}
+
+// SlowResponse reports whether the request took longer than the threshold.
+func (r *Response) SlowResponse(threshold time.Duration) bool {
+	return r.Duration > threshold
+}
+
+// RateLimit represents the rate limit information returned by Mailtrap in the response headers.
+type RateLimit struct {
+	// Limit is the number of requests allowed in the current window.
+	Limit int
+
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+
+	// Reset is the time at which the current window resets. Zero if not reported.
+	Reset time.Time
+
+	// RetryAfter is the delay requested by the server before retrying. Zero if not reported.
+	RetryAfter time.Duration
+}
+
+// ParseRateLimit parses the X-RateLimit-* and Retry-After headers of the response.
+// Missing headers are left at their zero value; malformed headers return an error.
+func (r *Response) ParseRateLimit() (*RateLimit, error) {
+	rl, err := r.parseRateLimit()
+	if err != nil {
+		return nil, err
+	}
+
+	return rl, nil
+}
+
+// RateLimit returns the rate limit reported by the headers of the response.
+// Absent or malformed headers are returned as zero values; use ParseRateLimit to detect malformed headers.
+func (r *Response) RateLimit() RateLimit {
+	rl, _ := r.parseRateLimit()
+	return *rl
+}
+
+// RateLimitLimit returns the X-RateLimit-Limit header value, or 0 if it is absent or malformed.
+func (r *Response) RateLimitLimit() int {
+	return r.RateLimit().Limit
+}
+
+// RateLimitRemaining returns the X-RateLimit-Remaining header value, or 0 if it is absent or malformed.
+func (r *Response) RateLimitRemaining() int {
+	return r.RateLimit().Remaining
+}
+
+// RateLimitReset returns the time of the X-RateLimit-Reset header, or the zero time if it is absent or malformed.
+func (r *Response) RateLimitReset() time.Time {
+	return r.RateLimit().Reset
+}
+
+// parseRateLimit parses every rate limit header, leaving malformed ones at their zero value,
+// and returns the first parse error alongside the result.
+func (r *Response) parseRateLimit() (*RateLimit, error) {
+	rl := &RateLimit{}
+	if r == nil || r.Response == nil {
+		return rl, nil
+	}
+
+	var firstErr error
+	parse := func(key string) int {
+		n, err := parseIntHeader(r.Header, key)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		return n
+	}
+
+	rl.Limit = parse("X-RateLimit-Limit")
+	rl.Remaining = parse("X-RateLimit-Remaining")
+	if reset := parse("X-RateLimit-Reset"); reset > 0 {
+		rl.Reset = time.Unix(int64(reset), 0)
+	}
+	rl.RetryAfter = time.Duration(parse("Retry-After")) * time.Second
+
+	return rl, firstErr
+}
+
+// IsExhausted reports whether no requests are left in the current window.
+func (rl *RateLimit) IsExhausted() bool {
+	return rl.Limit > 0 && rl.Remaining <= 0
+}
+
+// WaitDuration returns how long the caller should wait before making the next request.
+// Retry-After takes precedence; otherwise the time until Reset is used when the limit is exhausted.
+func (rl *RateLimit) WaitDuration() time.Duration {
+	if rl.RetryAfter > 0 {
+		return rl.RetryAfter
+	}
+	if rl.IsExhausted() && !rl.Reset.IsZero() {
+		if d := time.Until(rl.Reset); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
+// parseIntHeader returns the integer value of the header, or 0 if the header is absent.
+func parseIntHeader(h http.Header, key string) (int, error) {
+	v := h.Get(key)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s header %q: %w", key, v, err)
+	}
+
+	return n, nil
 }
 
 // checkResponse checks the API response for errors and returns them if present.
 // A response is considered an error if it has a status code outside the 200-299 range.
-func checkResponse(r *http.Response) error {
+func checkResponse(req *http.Request, r *http.Response, bodyLog *errorBodyLogger) error {
 	if c := r.StatusCode; c >= 200 && c <= 299 {
 		return nil
 	}
 	errResponse := &ErrorResponse{Response: r}
 	data, err := io.ReadAll(r.Body)
+	bodyLog.log(req, r.StatusCode, data)
 	if err == nil && len(data) > 0 {
 		err := json.Unmarshal(data, errResponse)
 		if err != nil {
 			errResponse.Message = string(data)
+		} else if isQuotaExceededResponse(errResponse) {
+			quotaErr := &QuotaExceededError{Response: r}
+			if err := json.Unmarshal(data, quotaErr); err == nil {
+				return quotaErr
+			}
 		}
 	}
 
 	return errResponse
 }
+
+// errorBodyLogger writes the raw bodies of error responses to w.
+type errorBodyLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// log writes the body of the error response to the request, masking values that look like API keys.
+func (l *errorBodyLogger) log(req *http.Request, status int, body []byte) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintf(l.w, "<-- %d %s %s\n%s\n", status, req.Method, req.URL, maskSecrets(body))
+}
+
+// secretPattern matches long alphanumeric strings that look like API keys.
+var secretPattern = regexp.MustCompile(`\b[A-Za-z0-9]{32,}\b`)
+
+// maskSecrets masks the values in data that look like API keys with MaskAPIKey.
+func maskSecrets(data []byte) []byte {
+	return secretPattern.ReplaceAllFunc(data, func(key []byte) []byte {
+		return []byte(MaskAPIKey(string(key)))
+	})
+}
@@ -1,7 +1,10 @@
 package mailtrap
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,7 +12,10 @@ import (
 	"net/url"
 	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // setupTestingClient sets up a test HTTP server for testing API client.
@@ -24,16 +30,19 @@ func setupTestingClient() (client *TestingClient, mux *http.ServeMux, teardown f
 }
 
 // setupSendingClient sets up a test HTTP server for sending API client.
-func setupSendingClient() (client SendingClient, mux *http.ServeMux, teardown func()) {
+func setupSendingClient(opts ...Option) (client SendingClient, mux *http.ServeMux, teardown func()) {
 	mux = http.NewServeMux()
 	server := httptest.NewServer(mux)
-	client, _ = NewSendingClient("api-token")
-	url, err := url.Parse(server.URL)
-	if err != nil {
-		panic(err)
-	}
-	
-	client.setBaseURL(*url)
+	client, _ = NewSendingClient("api-token", append([]Option{WithBaseURL(server.URL)}, opts...)...)
+
+	return client, mux, server.Close
+}
+
+// setupSandboxSendingClient sets up a test HTTP server for testing the sandbox sending client.
+func setupSandboxSendingClient(opts ...Option) (client SendingClient, mux *http.ServeMux, teardown func()) {
+	mux = http.NewServeMux()
+	server := httptest.NewServer(mux)
+	client, _ = NewSandboxSendingClient("api-token", 1, append([]Option{WithBaseURL(server.URL)}, opts...)...)
 
 	return client, mux, server.Close
 }
@@ -141,6 +150,290 @@ func TestNewTestingClient(t *testing.T) {
 	}
 }
 
+func TestWithBaseURL(t *testing.T) {
+	c, err := NewTestingClient("api-token", WithBaseURL("http://localhost:8080/api/"))
+	if err != nil {
+		t.Fatalf("Testing client returned error: %v", err)
+	}
+	if want := "http://localhost:8080/api"; c.baseURL.String() != want {
+		t.Errorf("Testing client baseURL is %s, want %s", c.baseURL.String(), want)
+	}
+
+	if _, err := NewSendingClient("api-token", WithBaseURL("://bad")); err == nil {
+		t.Error("Sending client with bad base URL, err = nil, want error")
+	}
+}
+
+func TestNewSendingClient_defaultTimeout(t *testing.T) {
+	sc, err := NewSendingClient("api-token")
+	if err != nil {
+		t.Fatalf("Sending client returned error: %v", err)
+	}
+	if got := sc.(*ProductionSendingClient).httpClient.Timeout; got != 30*time.Second {
+		t.Errorf("Sending client timeout is %v, want %v", got, 30*time.Second)
+	}
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	hc := &http.Client{Timeout: time.Minute}
+	c, err := NewTestingClient("api-token", WithHTTPClient(hc))
+	if err != nil {
+		t.Fatalf("Testing client returned error: %v", err)
+	}
+	if c.httpClient != hc {
+		t.Errorf("Testing client httpClient is %+v, want %+v", c.httpClient, hc)
+	}
+
+	if _, err := NewSendingClient("api-token", WithHTTPClient(nil)); err == nil {
+		t.Error("Sending client with nil HTTP client, err = nil, want error")
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	sc, err := NewSendingClient("api-token", WithTimeout(5*time.Second))
+	if err != nil {
+		t.Fatalf("Sending client returned error: %v", err)
+	}
+	if got := sc.(*ProductionSendingClient).httpClient.Timeout; got != 5*time.Second {
+		t.Errorf("Sending client timeout is %v, want %v", got, 5*time.Second)
+	}
+
+	hc := &http.Client{}
+	c, err := NewTestingClient("api-token", WithTimeout(5*time.Second), WithHTTPClient(hc))
+	if err != nil {
+		t.Fatalf("Testing client returned error: %v", err)
+	}
+	if c.httpClient.Timeout != 5*time.Second {
+		t.Errorf("Testing client timeout is %v, want %v", c.httpClient.Timeout, 5*time.Second)
+	}
+	if hc.Timeout != 0 || http.DefaultClient.Timeout != 0 {
+		t.Error("WithTimeout modified a shared HTTP client")
+	}
+
+	if _, err := NewSendingClient("api-token", WithTimeout(0)); err == nil {
+		t.Error("Sending client with zero timeout, err = nil, want error")
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+	if err := client.applyOptions([]Option{WithUserAgent("my-app/1.0")}); err != nil {
+		t.Fatalf("applyOptions returned error: %v", err)
+	}
+
+	var got string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+	})
+	req, _ := client.NewRequest(context.Background(), http.MethodGet, "/", nil)
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if got != "my-app/1.0" {
+		t.Errorf("User-Agent header is %q, want %q", got, "my-app/1.0")
+	}
+
+	if _, err := NewSendingClient("api-token", WithUserAgent("")); err == nil {
+		t.Error("Sending client with empty user agent, err = nil, want error")
+	}
+}
+
+func TestNewTestingClient_eagerVerify(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var calls int
+	mux.HandleFunc("/accounts", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			http.Error(w, `{"error":"Incorrect API token"}`, http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `[{"id":1,"name":"account"}]`)
+	})
+
+	if _, err := NewTestingClient("valid-token", WithBaseURL(server.URL), WithEagerVerify()); err != nil {
+		t.Errorf("Testing client returned error: %v", err)
+	}
+
+	c, err := NewTestingClient("invalid-token", WithBaseURL(server.URL), WithEagerVerify())
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Testing client returned error %v, want %v", err, ErrUnauthorized)
+	}
+	if c != nil {
+		t.Errorf("Testing client = %v, want nil", c)
+	}
+
+	if _, err := NewTestingClient("invalid-token", WithBaseURL(server.URL)); err != nil {
+		t.Errorf("Lazy testing client returned error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Accounts endpoint called %d times, want 2", calls)
+	}
+}
+
+func TestNewTestingClientWithContext_canceled(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/accounts", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("NewTestingClientWithContext verified the connection with a canceled context")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c, err := NewTestingClientWithContext(ctx, "api-token", WithBaseURL(server.URL), WithEagerVerify())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("NewTestingClientWithContext returned error %v, want %v", err, context.Canceled)
+	}
+	if c != nil {
+		t.Errorf("NewTestingClientWithContext = %v, want nil", c)
+	}
+}
+
+func TestNewAutoSendingClient(t *testing.T) {
+	tests := []struct {
+		mode, env   string
+		wantSandbox bool
+	}{
+		{mode: SendingModeAuto, env: "test", wantSandbox: true},
+		{mode: SendingModeAuto, env: "", wantSandbox: false},
+		{mode: SendingModeAuto, env: "production", wantSandbox: false},
+		{mode: SendingModeSandbox, env: "", wantSandbox: true},
+		{mode: SendingModeProduction, env: "test", wantSandbox: false},
+	}
+	for _, tt := range tests {
+		t.Setenv("MAILTRAP_ENV", tt.env)
+
+		c, err := NewAutoSendingClient("api-token", 1, tt.mode)
+		if err != nil {
+			t.Fatalf("NewAutoSendingClient(%q) with MAILTRAP_ENV=%q returned error: %v", tt.mode, tt.env, err)
+		}
+		if _, isSandbox := c.(*SandboxSendingClient); isSandbox != tt.wantSandbox {
+			t.Errorf("NewAutoSendingClient(%q) with MAILTRAP_ENV=%q = %T, want sandbox %v", tt.mode, tt.env, c, tt.wantSandbox)
+		}
+	}
+
+	if _, err := NewAutoSendingClient("api-token", 1, "staging"); err == nil {
+		t.Error("NewAutoSendingClient with unknown mode err = nil, want error")
+	}
+}
+
+func TestNewSendingClientFromEnv(t *testing.T) {
+	t.Setenv("MAILTRAP_API_KEY", "env-token")
+	t.Setenv("MAILTRAP_BASE_URL", "")
+
+	c, err := NewSendingClientFromEnv()
+	if err != nil {
+		t.Fatalf("NewSendingClientFromEnv returned error: %v", err)
+	}
+	sc := c.(*ProductionSendingClient)
+	if sc.apiKey != "env-token" {
+		t.Errorf("NewSendingClientFromEnv apiKey is %s, want %s", sc.apiKey, "env-token")
+	}
+	if want := sendingAPIURL + apiSuffix; sc.baseURL.String() != want {
+		t.Errorf("NewSendingClientFromEnv baseURL is %s, want %s", sc.baseURL.String(), want)
+	}
+
+	t.Setenv("MAILTRAP_BASE_URL", "http://localhost:8025/api/")
+	c, err = NewSendingClientFromEnv()
+	if err != nil {
+		t.Fatalf("NewSendingClientFromEnv returned error: %v", err)
+	}
+	if got := c.(*ProductionSendingClient).baseURL.String(); got != "http://localhost:8025/api" {
+		t.Errorf("NewSendingClientFromEnv baseURL is %s, want %s", got, "http://localhost:8025/api")
+	}
+
+	c, err = NewSendingClientFromEnv(WithBaseURL("http://localhost:9000/api"))
+	if err != nil {
+		t.Fatalf("NewSendingClientFromEnv returned error: %v", err)
+	}
+	if got := c.(*ProductionSendingClient).baseURL.String(); got != "http://localhost:9000/api" {
+		t.Errorf("NewSendingClientFromEnv with WithBaseURL baseURL is %s, want the option to win", got)
+	}
+
+	t.Setenv("MAILTRAP_API_KEY", "")
+	if _, err := NewSendingClientFromEnv(); err == nil || !strings.Contains(err.Error(), "MAILTRAP_API_KEY") {
+		t.Errorf("NewSendingClientFromEnv without API key returned error %v, want it to name MAILTRAP_API_KEY", err)
+	}
+}
+
+func TestNewSandboxSendingClientFromEnv(t *testing.T) {
+	t.Setenv("MAILTRAP_API_KEY", "env-token")
+	t.Setenv("MAILTRAP_BASE_URL", "")
+	t.Setenv("MAILTRAP_INBOX_ID", "42")
+
+	c, err := NewSandboxSendingClientFromEnv()
+	if err != nil {
+		t.Fatalf("NewSandboxSendingClientFromEnv returned error: %v", err)
+	}
+	sc := c.(*SandboxSendingClient)
+	if sc.apiKey != "env-token" || sc.inboxID != 42 {
+		t.Errorf("NewSandboxSendingClientFromEnv apiKey, inboxID = %s, %d, want %s, %d", sc.apiKey, sc.inboxID, "env-token", 42)
+	}
+	if want := sandboxAPIURL + apiSuffix; sc.baseURL.String() != want {
+		t.Errorf("NewSandboxSendingClientFromEnv baseURL is %s, want %s", sc.baseURL.String(), want)
+	}
+
+	for _, inboxID := range []string{"", "inbox"} {
+		t.Setenv("MAILTRAP_INBOX_ID", inboxID)
+		if _, err := NewSandboxSendingClientFromEnv(); err == nil || !strings.Contains(err.Error(), "MAILTRAP_INBOX_ID") {
+			t.Errorf("NewSandboxSendingClientFromEnv with MAILTRAP_INBOX_ID=%q returned error %v, want it to name MAILTRAP_INBOX_ID", inboxID, err)
+		}
+	}
+
+	t.Setenv("MAILTRAP_API_KEY", "")
+	t.Setenv("MAILTRAP_INBOX_ID", "42")
+	if _, err := NewSandboxSendingClientFromEnv(); err == nil || !strings.Contains(err.Error(), "MAILTRAP_API_KEY") {
+		t.Errorf("NewSandboxSendingClientFromEnv without API key returned error %v, want it to name MAILTRAP_API_KEY", err)
+	}
+}
+
+func TestNewTestingClientFromEnv(t *testing.T) {
+	t.Setenv("MAILTRAP_API_KEY", "env-token")
+	t.Setenv("MAILTRAP_BASE_URL", "http://localhost:8025/api")
+
+	c, err := NewTestingClientFromEnv()
+	if err != nil {
+		t.Fatalf("NewTestingClientFromEnv returned error: %v", err)
+	}
+	if c.apiKey != "env-token" {
+		t.Errorf("NewTestingClientFromEnv apiKey is %s, want %s", c.apiKey, "env-token")
+	}
+	if got := c.baseURL.String(); got != "http://localhost:8025/api" {
+		t.Errorf("NewTestingClientFromEnv baseURL is %s, want %s", got, "http://localhost:8025/api")
+	}
+
+	t.Setenv("MAILTRAP_API_KEY", "")
+	if _, err := NewTestingClientFromEnv(); err == nil || !strings.Contains(err.Error(), "MAILTRAP_API_KEY") {
+		t.Errorf("NewTestingClientFromEnv without API key returned error %v, want it to name MAILTRAP_API_KEY", err)
+	}
+}
+
+func TestAPIKeyLast4(t *testing.T) {
+	tests := []struct {
+		key, last4, masked string
+	}{
+		{key: "", last4: "****", masked: ""},
+		{key: "abc", last4: "****", masked: "***"},
+		{key: "abcd", last4: "abcd", masked: "****"},
+		{key: "secret-api-key", last4: "-key", masked: "**********-key"},
+	}
+	for _, tt := range tests {
+		c, _ := NewTestingClient(tt.key)
+		if got := c.APIKeyLast4(); got != tt.last4 {
+			t.Errorf("APIKeyLast4() for %q = %q, want %q", tt.key, got, tt.last4)
+		}
+		if got := MaskAPIKey(tt.key); got != tt.masked {
+			t.Errorf("MaskAPIKey(%q) = %q, want %q", tt.key, got, tt.masked)
+		}
+	}
+}
+
 func TestNewRequest(t *testing.T) {
 	c, _ := NewTestingClient("")
 
@@ -152,7 +445,7 @@ func TestNewRequest(t *testing.T) {
 	}
 	outBody := `{"resource_id":1,"resource_type":"account","access_level":"100"}`
 
-	req, _ := c.NewRequest(http.MethodPost, inURL, inBody)
+	req, _ := c.NewRequest(context.Background(), http.MethodPost, inURL, inBody)
 
 	if req.URL.String() != outURL {
 		t.Errorf("NewRequest(%v) URL = %v, expected %v", inURL, req.URL, outURL)
@@ -169,6 +462,92 @@ func TestNewRequest(t *testing.T) {
 	}
 }
 
+func TestNewRequest_userAgent(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	var got []string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("User-Agent"))
+	})
+
+	requests := []RequestOption{
+		WithRequestUserAgent("experiment-b/1.0"),
+		nil,
+		WithRequestUserAgent(""),
+	}
+	for _, opt := range requests {
+		var opts []RequestOption
+		if opt != nil {
+			opts = append(opts, opt)
+		}
+		req, err := client.NewRequest(context.Background(), http.MethodGet, "/", nil, opts...)
+		if err != nil {
+			t.Fatalf("NewRequest returned error: %v", err)
+		}
+		if _, err := client.Do(context.Background(), req, nil); err != nil {
+			t.Fatalf("Do returned error: %v", err)
+		}
+	}
+
+	want := []string{"experiment-b/1.0", client.userAgent, client.userAgent}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("User-Agent headers = %q, want %q", got, want)
+	}
+}
+
+func TestNewRequest_preProcessors(t *testing.T) {
+	var order []string
+	c, err := NewTestingClient("",
+		WithRequestPreProcessor(func(req *http.Request) error {
+			order = append(order, "first")
+			req.Header.Set("X-Signature", "signed")
+			return nil
+		}),
+		WithRequestPreProcessor(func(req *http.Request) error {
+			order = append(order, "second")
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewTestingClient returned error: %v", err)
+	}
+
+	req, err := c.NewRequest(context.Background(), http.MethodGet, "/accounts", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if got := req.Header.Get("X-Signature"); got != "signed" {
+		t.Errorf("NewRequest() X-Signature = %q, want %q", got, "signed")
+	}
+	if want := []string{"first", "second"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("pre-processors ran in order %q, want %q", order, want)
+	}
+}
+
+func TestNewRequest_preProcessorError(t *testing.T) {
+	errSign := errors.New("sign failed")
+	called := false
+	c, _ := NewTestingClient("",
+		WithRequestPreProcessor(func(req *http.Request) error { return errSign }),
+		WithRequestPreProcessor(func(req *http.Request) error {
+			called = true
+			return nil
+		}),
+	)
+
+	req, err := c.NewRequest(context.Background(), http.MethodGet, "/accounts", nil)
+	if !errors.Is(err, errSign) {
+		t.Errorf("NewRequest returned error %v, want %v", err, errSign)
+	}
+	if req != nil {
+		t.Errorf("NewRequest returned request %v, want nil", req)
+	}
+	if called {
+		t.Error("pre-processor after a failing one was called")
+	}
+}
+
 func TestDo(t *testing.T) {
 	client, mux, teardown := setupTestingClient()
 	defer teardown()
@@ -182,9 +561,9 @@ func TestDo(t *testing.T) {
 		fmt.Fprint(w, `{"ID":"1234567890"}`)
 	})
 
-	req, _ := client.NewRequest("GET", "/", nil)
+	req, _ := client.NewRequest(context.Background(), "GET", "/", nil)
 	body := new(account)
-	_, _ = client.Do(req, body)
+	_, _ = client.Do(context.Background(), req, body)
 
 	want := &account{"1234567890"}
 	if !reflect.DeepEqual(body, want) {
@@ -192,6 +571,167 @@ func TestDo(t *testing.T) {
 	}
 }
 
+func TestDo_duration(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	const sleep = 50 * time.Millisecond
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(sleep)
+		fmt.Fprint(w, `{}`)
+	})
+
+	req, _ := client.NewRequest(context.Background(), "GET", "/", nil)
+	resp, err := client.Do(context.Background(), req, nil)
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	if resp.Duration < sleep {
+		t.Errorf("Response.Duration = %v, want at least %v", resp.Duration, sleep)
+	}
+	if !resp.SlowResponse(sleep / 2) {
+		t.Errorf("Response.SlowResponse(%v) = false, want true", sleep/2)
+	}
+	if resp.SlowResponse(time.Minute) {
+		t.Errorf("Response.SlowResponse(%v) = true, want false", time.Minute)
+	}
+}
+
+func TestDo_maxConcurrentRequests(t *testing.T) {
+	const limit = 3
+
+	var inFlight, maxInFlight atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client, err := NewTestingClient("api-token", WithBaseURL(server.URL), WithMaxConcurrentRequests(limit))
+	if err != nil {
+		t.Fatalf("NewTestingClient returned error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < limit*4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := client.NewRequest(context.Background(), http.MethodGet, "/", nil)
+			if _, err := client.Do(context.Background(), req, nil); err != nil {
+				t.Errorf("Do returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := maxInFlight.Load(); got > limit {
+		t.Errorf("max concurrent requests = %d, want at most %d", got, limit)
+	}
+
+	if _, err := NewTestingClient("api-token", WithMaxConcurrentRequests(-1)); err == nil {
+		t.Error("NewTestingClient with negative max concurrent requests err = nil, want error")
+	}
+}
+
+func TestDo_operationTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client, err := NewTestingClient("api-token", WithBaseURL(server.URL), WithOperationTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewTestingClient returned error: %v", err)
+	}
+
+	req, _ := client.NewRequest(context.Background(), http.MethodGet, "/", nil)
+	start := time.Now()
+	_, err = client.Do(context.Background(), req, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Do took %v, want it to be canceled after about 100ms", elapsed)
+	}
+}
+
+func TestDo_requestContextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client, err := NewTestingClient("api-token", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewTestingClient returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	start := time.Now()
+	if _, err := client.Do(context.Background(), req, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Do returned error %v, want %v", err, context.Canceled)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Do took %v, want it to be canceled with the request context", elapsed)
+	}
+}
+
+func TestDo_rateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	client, err := NewTestingClient("api-token", WithBaseURL(server.URL), WithRateLimit(1, 1))
+	if err != nil {
+		t.Fatalf("NewTestingClient returned error: %v", err)
+	}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		req, _ := client.NewRequest(context.Background(), http.MethodGet, "/", nil)
+		if _, err := client.Do(context.Background(), req, nil); err != nil {
+			t.Errorf("Do returned error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 2*time.Second {
+		t.Errorf("3 requests at 1 req/s took %v, want at least 2s", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	if _, err := client.Do(ctx, req, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Do returned error %v, want %v", err, context.Canceled)
+	}
+
+	for _, opt := range []Option{WithRateLimit(0, 1), WithRateLimit(1, 0)} {
+		if _, err := NewTestingClient("api-token", opt); err == nil {
+			t.Error("NewTestingClient with invalid rate limit err = nil, want error")
+		}
+	}
+}
+
 func TestDo_httpBadRequest(t *testing.T) {
 	client, mux, teardown := setupTestingClient()
 	defer teardown()
@@ -200,8 +740,8 @@ func TestDo_httpBadRequest(t *testing.T) {
 		http.Error(w, "Bad Request", 400)
 	})
 
-	req, _ := client.NewRequest("GET", "/", nil)
-	resp, err := client.Do(req, nil)
+	req, _ := client.NewRequest(context.Background(), "GET", "/", nil)
+	resp, err := client.Do(context.Background(), req, nil)
 
 	if err == nil {
 		t.Fatal("Expected HTTP 400 error, got no error.")
@@ -219,13 +759,18 @@ func TestDo_redirectLoop(t *testing.T) {
 		http.Redirect(w, r, "", http.StatusFound)
 	})
 
-	req, _ := client.NewRequest("GET", "/", nil)
-	_, err := client.Do(req, nil)
+	req, _ := client.NewRequest(context.Background(), "GET", "/", nil)
+	_, err := client.Do(context.Background(), req, nil)
 
 	if err == nil {
 		t.Error("Expected error to be returned.")
 	}
-	if err, ok := err.(*url.Error); !ok {
+	var cerr *ClientError
+	if !errors.As(err, &cerr) {
+		t.Errorf("Expected a client error; got %#v.", err)
+	}
+	var uerr *url.Error
+	if !errors.As(err, &uerr) {
 		t.Errorf("Expected a URL error; got %#v.", err)
 	}
 }
@@ -239,15 +784,213 @@ func TestDo_decodeUndefinedType(t *testing.T) {
 		fmt.Fprint(w, "From: <jd@example.com> To: <info@example.com> Subject: Hello, world!")
 	})
 
-	req, _ := client.NewRequest("GET", "/", nil)
+	req, _ := client.NewRequest(context.Background(), "GET", "/", nil)
 	req.Header.Set("Accept", "image/jpeg")
 
 	body := new(int)
-	if _, err := client.Do(req, body); err == nil {
+	if _, err := client.Do(context.Background(), req, body); err == nil {
 		t.Error("Expected error to be returned.")
 	}
 }
 
+func TestResponse_ParseRateLimit(t *testing.T) {
+	reset := time.Now().Add(time.Minute).Truncate(time.Second)
+	resp := &Response{Response: &http.Response{Header: http.Header{}}}
+	resp.Header.Set("X-RateLimit-Limit", "150")
+	resp.Header.Set("X-RateLimit-Remaining", "0")
+	resp.Header.Set("X-RateLimit-Reset", fmt.Sprintf("%d", reset.Unix()))
+	resp.Header.Set("Retry-After", "5")
+
+	rl, err := resp.ParseRateLimit()
+	if err != nil {
+		t.Fatalf("ParseRateLimit returned error: %v", err)
+	}
+
+	want := &RateLimit{Limit: 150, Remaining: 0, Reset: reset, RetryAfter: 5 * time.Second}
+	if !reflect.DeepEqual(rl, want) {
+		t.Errorf("ParseRateLimit returned %+v, want %+v", rl, want)
+	}
+	if !rl.IsExhausted() {
+		t.Error("RateLimit.IsExhausted returned false, want true")
+	}
+	if got := rl.WaitDuration(); got != 5*time.Second {
+		t.Errorf("RateLimit.WaitDuration returned %v, want %v", got, 5*time.Second)
+	}
+
+	rl.RetryAfter = 0
+	if got := rl.WaitDuration(); got <= 0 || got > time.Minute {
+		t.Errorf("RateLimit.WaitDuration returned %v, want (0, 1m]", got)
+	}
+}
+
+func TestResponse_ParseRateLimit_missingReset(t *testing.T) {
+	resp := &Response{Response: &http.Response{Header: http.Header{}}}
+	resp.Header.Set("X-RateLimit-Limit", "150")
+	resp.Header.Set("X-RateLimit-Remaining", "20")
+
+	rl, err := resp.ParseRateLimit()
+	if err != nil {
+		t.Fatalf("ParseRateLimit returned error: %v", err)
+	}
+
+	want := &RateLimit{Limit: 150, Remaining: 20}
+	if !reflect.DeepEqual(rl, want) {
+		t.Errorf("ParseRateLimit returned %+v, want %+v", rl, want)
+	}
+	if rl.IsExhausted() {
+		t.Error("RateLimit.IsExhausted returned true, want false")
+	}
+	if got := rl.WaitDuration(); got != 0 {
+		t.Errorf("RateLimit.WaitDuration returned %v, want 0", got)
+	}
+}
+
+func TestResponse_ParseRateLimit_invalidHeader(t *testing.T) {
+	resp := &Response{Response: &http.Response{Header: http.Header{}}}
+	resp.Header.Set("X-RateLimit-Remaining", "many")
+
+	if _, err := resp.ParseRateLimit(); err == nil {
+		t.Error("ParseRateLimit err = nil, want error")
+	}
+}
+
+func TestResponse_RateLimit(t *testing.T) {
+	reset := time.Now().Add(time.Minute).Truncate(time.Second)
+	resp := &Response{Response: &http.Response{Header: http.Header{}}}
+	resp.Header.Set("X-RateLimit-Limit", "150")
+	resp.Header.Set("X-RateLimit-Remaining", "20")
+	resp.Header.Set("X-RateLimit-Reset", fmt.Sprintf("%d", reset.Unix()))
+
+	want := RateLimit{Limit: 150, Remaining: 20, Reset: reset}
+	if got := resp.RateLimit(); !reflect.DeepEqual(got, want) {
+		t.Errorf("RateLimit returned %+v, want %+v", got, want)
+	}
+	if got := resp.RateLimitLimit(); got != 150 {
+		t.Errorf("RateLimitLimit returned %d, want 150", got)
+	}
+	if got := resp.RateLimitRemaining(); got != 20 {
+		t.Errorf("RateLimitRemaining returned %d, want 20", got)
+	}
+	if got := resp.RateLimitReset(); !got.Equal(reset) {
+		t.Errorf("RateLimitReset returned %v, want %v", got, reset)
+	}
+}
+
+func TestResponse_RateLimit_missingHeaders(t *testing.T) {
+	resp := &Response{Response: &http.Response{Header: http.Header{}}}
+	resp.Header.Set("X-RateLimit-Limit", "many")
+	resp.Header.Set("X-RateLimit-Remaining", "20")
+
+	want := RateLimit{Remaining: 20}
+	if got := resp.RateLimit(); !reflect.DeepEqual(got, want) {
+		t.Errorf("RateLimit returned %+v, want %+v", got, want)
+	}
+
+	var nilResp *Response
+	if got := nilResp.RateLimit(); !reflect.DeepEqual(got, RateLimit{}) {
+		t.Errorf("RateLimit of nil response returned %+v, want zero values", got)
+	}
+}
+
 func TestCheckResponse(t *testing.T) {
 	t.Skip()
 }
+
+func TestWithErrorBodyLogging(t *testing.T) {
+	var buf bytes.Buffer
+	client, mux, teardown := setupSendingClient(WithErrorBodyLogging(&buf))
+	defer teardown()
+
+	mux.HandleFunc("/unavailable", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, "Service Unavailable")
+	})
+	mux.HandleFunc("/unauthorized", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"errors":["Unauthorized"],"token":"0123456789abcdef0123456789abcdef"}`)
+	})
+
+	req, _ := client.NewRequest(context.Background(), http.MethodGet, "/unavailable", nil)
+	if _, err := client.Do(context.Background(), req, nil); err == nil {
+		t.Error("Expected error to be returned.")
+	}
+	want := fmt.Sprintf("<-- 503 GET %s\nService Unavailable\n", req.URL)
+	if got := buf.String(); got != want {
+		t.Errorf("error body log = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	req, _ = client.NewRequest(context.Background(), http.MethodGet, "/unauthorized", nil)
+	if _, err := client.Do(context.Background(), req, nil); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Do returned error %v, want %v", err, ErrUnauthorized)
+	}
+	if got := buf.String(); strings.Contains(got, "0123456789abcdef0123") ||
+		!strings.Contains(got, `"token":"****************************cdef"`) {
+		t.Errorf("error body log = %q, want the token masked", got)
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithErrorBodyLogging_responseWithoutRequest(t *testing.T) {
+	var buf bytes.Buffer
+	transport := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusBadGateway,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("Bad Gateway")),
+		}, nil
+	})
+	client, err := NewTestingClient("api-token", WithTransport(transport), WithErrorBodyLogging(&buf))
+	if err != nil {
+		t.Fatalf("NewTestingClient returned error: %v", err)
+	}
+
+	req, _ := client.NewRequest(context.Background(), http.MethodGet, "/accounts", nil)
+	_, err = client.Do(context.Background(), req, nil)
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusBadGateway {
+		t.Fatalf("Do returned error %v, want a 502 error response", err)
+	}
+	if !strings.Contains(err.Error(), req.URL.String()) {
+		t.Errorf("Do returned error %q, want it to contain the request URL", err)
+	}
+	if want := fmt.Sprintf("<-- 502 GET %s\nBad Gateway\n", req.URL); buf.String() != want {
+		t.Errorf("error body log = %q, want %q", buf.String(), want)
+	}
+}
+
+// TestWithErrorBodyLogging_concurrent is meant to be run with the race detector.
+func TestWithErrorBodyLogging_concurrent(t *testing.T) {
+	var buf bytes.Buffer
+	client, mux, teardown := setupSendingClient(WithErrorBodyLogging(&buf))
+	defer teardown()
+
+	mux.HandleFunc("/unavailable", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, "Service Unavailable")
+	})
+
+	const goroutines = 20
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := client.NewRequest(context.Background(), http.MethodGet, "/unavailable", nil)
+			if _, err := client.Do(context.Background(), req, nil); err == nil {
+				t.Error("Expected error to be returned.")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := strings.Count(buf.String(), "Service Unavailable\n"); n != goroutines {
+		t.Errorf("error body log has %d entries, want %d", n, goroutines)
+	}
+}
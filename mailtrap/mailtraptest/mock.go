@@ -0,0 +1,106 @@
+// Package mailtraptest provides test doubles for code that depends on the mailtrap package.
+package mailtraptest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/bennovw/mailtrap-go/mailtrap"
+)
+
+// mockBaseURL is the base URL of the requests created by MockSendingClient.NewRequest.
+const mockBaseURL = "https://send.api.mailtrap.io/api"
+
+// MockSendingClient is a mailtrap.SendingClient that records the sent requests instead of
+// sending them, for unit tests of code that sends emails. The zero value is ready to use
+// and answers every Send with a successful response.
+//
+//	mock := &mailtraptest.MockSendingClient{}
+//	notifier := NewNotifier(mock)
+//	...
+//	if len(mock.Calls) != 1 {
+//		t.Errorf("sent %d emails, want 1", len(mock.Calls))
+//	}
+type MockSendingClient struct {
+	// SendFunc is called by Send when it is set, so that tests can return custom responses or errors.
+	SendFunc func(*mailtrap.SendEmailRequest) (*mailtrap.SendEmailResponse, *mailtrap.Response, error)
+
+	// Calls are the requests passed to Send, in order.
+	Calls []*mailtrap.SendEmailRequest
+
+	mu sync.Mutex
+}
+
+var _ mailtrap.SendingClient = &MockSendingClient{}
+
+// Send records the request and returns the result of SendFunc, or a successful response
+// with one message ID per call if SendFunc is not set.
+func (m *MockSendingClient) Send(
+	ctx context.Context,
+	request *mailtrap.SendEmailRequest,
+) (*mailtrap.SendEmailResponse, *mailtrap.Response, error) {
+	m.mu.Lock()
+	m.Calls = append(m.Calls, request)
+	n := len(m.Calls)
+	sendFunc := m.SendFunc
+	m.mu.Unlock()
+
+	if sendFunc != nil {
+		return sendFunc(request)
+	}
+
+	resp := &mailtrap.SendEmailResponse{
+		Success:    true,
+		MessageIDs: []string{fmt.Sprintf("mock-message-%d", n)},
+	}
+	res := &mailtrap.Response{
+		Response: &http.Response{Status: "200 OK", StatusCode: http.StatusOK, Header: http.Header{}},
+	}
+
+	return resp, res, nil
+}
+
+// Reset forgets the recorded calls. SendFunc is kept.
+func (m *MockSendingClient) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Calls = nil
+}
+
+// NewRequest creates a request for the path with the JSON-encoded body, like the real client.
+func (m *MockSendingClient) NewRequest(
+	ctx context.Context,
+	method, path string,
+	body interface{},
+	opts ...mailtrap.RequestOption,
+) (*http.Request, error) {
+	buf := new(bytes.Buffer)
+	if body != nil {
+		if err := json.NewEncoder(buf).Encode(body); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, mockBaseURL+path, buf)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	return req, nil
+}
+
+// ErrNotSupported is returned by MockSendingClient.Do, which does not send requests.
+var ErrNotSupported = errors.New("mailtraptest: Do is not supported by MockSendingClient")
+
+// Do returns ErrNotSupported.
+func (m *MockSendingClient) Do(ctx context.Context, req *http.Request, v interface{}) (*mailtrap.Response, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,105 @@
+package mailtraptest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bennovw/mailtrap-go/mailtrap"
+)
+
+func emailRequest(subject string) *mailtrap.SendEmailRequest {
+	return &mailtrap.SendEmailRequest{
+		From:    mailtrap.EmailAddress{Email: "ches@example.com"},
+		To:      []mailtrap.EmailAddress{{Email: "john@example.com"}},
+		Subject: subject,
+		Text:    "Thank you for your order.",
+	}
+}
+
+func TestMockSendingClient(t *testing.T) {
+	var client mailtrap.SendingClient = &MockSendingClient{}
+	mock := client.(*MockSendingClient)
+
+	for i, subject := range []string{"First", "Second"} {
+		req := emailRequest(subject)
+		resp, res, err := client.Send(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Send returned error: %v", err)
+		}
+		if !resp.Success || len(resp.MessageIDs) != 1 {
+			t.Errorf("Send returned %+v, want a successful response", resp)
+		}
+		if res.StatusCode != http.StatusOK {
+			t.Errorf("Send returned status %d, want %d", res.StatusCode, http.StatusOK)
+		}
+		if len(mock.Calls) != i+1 || mock.Calls[i] != req {
+			t.Errorf("Calls = %+v, want %d calls ending with the sent request", mock.Calls, i+1)
+		}
+	}
+
+	mock.Reset()
+	if len(mock.Calls) != 0 {
+		t.Errorf("Calls after Reset = %+v, want none", mock.Calls)
+	}
+}
+
+func TestMockSendingClient_SendFunc(t *testing.T) {
+	sendErr := errors.New("quota exceeded")
+	mock := &MockSendingClient{
+		SendFunc: func(r *mailtrap.SendEmailRequest) (*mailtrap.SendEmailResponse, *mailtrap.Response, error) {
+			if r.Subject == "fail" {
+				return nil, nil, sendErr
+			}
+			return &mailtrap.SendEmailResponse{Success: true, MessageIDs: []string{"custom"}}, nil, nil
+		},
+	}
+
+	resp, _, err := mock.Send(context.Background(), emailRequest("ok"))
+	if err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	want := &mailtrap.SendEmailResponse{Success: true, MessageIDs: []string{"custom"}}
+	if !reflect.DeepEqual(resp, want) {
+		t.Errorf("Send returned %+v, want %+v", resp, want)
+	}
+
+	if _, _, err := mock.Send(context.Background(), emailRequest("fail")); !errors.Is(err, sendErr) {
+		t.Errorf("Send returned error %v, want %v", err, sendErr)
+	}
+	if len(mock.Calls) != 2 {
+		t.Errorf("Calls has %d requests, want 2", len(mock.Calls))
+	}
+
+	mock.Reset()
+	if _, _, err := mock.Send(context.Background(), emailRequest("fail")); !errors.Is(err, sendErr) {
+		t.Errorf("Send after Reset returned error %v, want SendFunc to be kept", err)
+	}
+}
+
+func TestMockSendingClient_NewRequestAndDo(t *testing.T) {
+	mock := &MockSendingClient{}
+
+	req, err := mock.NewRequest(context.Background(), http.MethodPost, "/send", map[string]string{"subject": "Hi"},
+		mailtrap.WithRequestUserAgent("my-app/1.0"))
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if got := req.URL.String(); got != "https://send.api.mailtrap.io/api/send" {
+		t.Errorf("NewRequest URL = %q", got)
+	}
+	if got := req.Header.Get("User-Agent"); got != "my-app/1.0" {
+		t.Errorf("NewRequest User-Agent = %q, want the request option to be applied", got)
+	}
+	body, _ := io.ReadAll(req.Body)
+	if string(body) != "{\"subject\":\"Hi\"}\n" {
+		t.Errorf("NewRequest body = %q", body)
+	}
+
+	if _, err := mock.Do(context.Background(), req, nil); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Do returned error %v, want %v", err, ErrNotSupported)
+	}
+}
@@ -1,25 +1,67 @@
 package mailtrap
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
 	"net/http"
 	"net/mail"
+	"net/textproto"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type MessagesServiceContract interface {
 	List(accountID, inboxID int) ([]*Message, *Response, error)
+	ListWithContext(ctx context.Context, accountID, inboxID int) ([]*Message, *Response, error)
+	ListUnread(ctx context.Context, accountID, inboxID int) ([]*Message, *Response, error)
+	CountUnread(ctx context.Context, accountID, inboxID int) (int, *Response, error)
+	GetBySubject(ctx context.Context, accountID, inboxID int, subject string) (*Message, *Response, error)
+	GetBySubjectContains(ctx context.Context, accountID, inboxID int, substring string) (*Message, *Response, error)
 	Get(accountID, inboxID, messageID int) (*Message, *Response, error)
+	GetWithContext(ctx context.Context, accountID, inboxID, messageID int) (*Message, *Response, error)
 	Update(accountID, inboxID, messageID int, updateReq *UpdateMessageRequest) (*Message, *Response, error)
+	UpdateWithContext(ctx context.Context, accountID, inboxID, messageID int, updateReq *UpdateMessageRequest) (*Message, *Response, error)
 	Delete(accountID, inboxID, messageID int) (*Response, error)
+	DeleteWithContext(ctx context.Context, accountID, inboxID, messageID int) (*Response, error)
 	Forward(accountID, inboxID, messageID int, email string) (*Response, error)
+	ForwardWithContext(ctx context.Context, accountID, inboxID, messageID int, email string) (*Response, error)
 	SpamReport(accountID, inboxID, messageID int) (*SpamReport, *Response, error)
+	SpamReportWithContext(ctx context.Context, accountID, inboxID, messageID int) (*SpamReport, *Response, error)
 	AsRaw(accountID, inboxID, messageID int) (string, *Response, error)
+	AsRawWithContext(ctx context.Context, accountID, inboxID, messageID int) (string, *Response, error)
 	AsText(accountID, inboxID, messageID int) (string, *Response, error)
+	AsTextWithContext(ctx context.Context, accountID, inboxID, messageID int) (string, *Response, error)
 	AsHTML(accountID, inboxID, messageID int) (string, *Response, error)
+	AsHTMLWithContext(ctx context.Context, accountID, inboxID, messageID int) (string, *Response, error)
 	AsHTMLSource(accountID, inboxID, messageID int) (string, *Response, error)
+	AsHTMLSourceWithContext(ctx context.Context, accountID, inboxID, messageID int) (string, *Response, error)
 	AsEML(accountID, inboxID, messageID int) (string, *Response, error)
+	AsEMLWithContext(ctx context.Context, accountID, inboxID, messageID int) (string, *Response, error)
+	GetAsEML(ctx context.Context, accountID, inboxID, messageID int) ([]byte, *Response, error)
+	SaveAsEML(ctx context.Context, accountID, inboxID, messageID int, path string) error
+	GetBodyParts(ctx context.Context, accountID, inboxID, messageID int) (*MessageBody, *Response, error)
+	GetHeaders(ctx context.Context, accountID, inboxID, messageID int) (MailHeaders, *Response, error)
+	GetAttachmentContent(
+		ctx context.Context,
+		accountID, inboxID, messageID int,
+		filename string,
+	) ([]byte, string, *Response, error)
+	WaitForMessage(
+		ctx context.Context,
+		accountID, inboxID int,
+		interval time.Duration,
+		match func(*Message) bool,
+	) (*Message, *Response, error)
 }
 
 type MessagesService struct {
@@ -38,8 +80,15 @@ type Message struct {
 	FromName             string           `json:"from_name"`
 	ToEmail              string           `json:"to_email"`
 	ToName               string           `json:"to_name"`
+	Category             string           `json:"category"`
 	EmailSize            int              `json:"email_size"`
 	IsRead               bool             `json:"is_read"`
+	HTMLBody             string           `json:"html_body"`
+	TextBody             string           `json:"text_body"`
+	SpamScore            float64          `json:"spam_score"`
+	IsSpam               bool             `json:"is_spam"`
+	BounceCategory       string           `json:"bounce_category"`
+	RawSize              int              `json:"raw_size"`
 	CreatedAt            time.Time        `json:"created_at"`
 	UpdatedAt            time.Time        `json:"updated_at"`
 	HTMLBodySize         int              `json:"html_body_size"`
@@ -52,6 +101,19 @@ type Message struct {
 	HTMLSourcePath       string           `json:"html_source_path"`
 	BlacklistsReportInfo bool             `json:"blacklists_report_info"`
 	SMTPInfo             *MessageSMTPInfo `json:"smtp_information"`
+
+	// The Message-ID header of the message, if provided.
+	MessageID string `json:"message_id,omitempty"`
+}
+
+// SentTime returns the time the message was sent, falling back to the time
+// it was received by the inbox when the sending time is unknown.
+func (m *Message) SentTime() time.Time {
+	if m.SentAt.IsZero() {
+		return m.CreatedAt
+	}
+
+	return m.SentAt
 }
 
 // MessageSMTPInfo represents a Mailtrap message SMTP information.
@@ -76,18 +138,101 @@ type SpamReport struct {
 	} `json:"report"`
 }
 
+// SpamRule is a spam filter rule matched by a message.
+type SpamRule struct {
+	Name        string  `json:"name"`
+	Score       float64 `json:"score"`
+	Description string  `json:"description"`
+	MatchedText string  `json:"matched_text,omitempty"`
+}
+
+// Rules returns the matched spam rules listed in the report details, in report order.
+// Details that are not rule objects are skipped.
+func (r *SpamReport) Rules() []SpamRule {
+	var rules []SpamRule
+	for _, d := range r.Report.Details {
+		fields, ok := d.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rules = append(rules, SpamRule{
+			Name:        stringField(fields, "RuleName", "name"),
+			Score:       scoreField(fields, "Pts", "score"),
+			Description: stringField(fields, "Description", "description"),
+			MatchedText: stringField(fields, "MatchedText", "matched_text"),
+		})
+	}
+
+	return rules
+}
+
+// RulesByScore returns the matched spam rules sorted by descending score.
+func (r *SpamReport) RulesByScore() []SpamRule {
+	rules := r.Rules()
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Score > rules[j].Score
+	})
+
+	return rules
+}
+
+// TotalScore returns the sum of the positive rule scores.
+func (r *SpamReport) TotalScore() float64 {
+	var total float64
+	for _, rule := range r.Rules() {
+		if rule.Score > 0 {
+			total += rule.Score
+		}
+	}
+
+	return total
+}
+
+// stringField returns the first of the keys present in fields as a string.
+func stringField(fields map[string]interface{}, keys ...string) string {
+	for _, k := range keys {
+		if v, ok := fields[k].(string); ok {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// scoreField returns the first of the keys present in fields as a number.
+// Scores may be encoded as JSON numbers or as numeric strings.
+func scoreField(fields map[string]interface{}, keys ...string) float64 {
+	for _, k := range keys {
+		switch v := fields[k].(type) {
+		case float64:
+			return v
+		case string:
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return f
+			}
+		}
+	}
+
+	return 0
+}
+
 // List returns all messages in inboxs.
 //
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/a80869adf4489-get-messages
 func (s *MessagesService) List(accountID, inboxID int) ([]*Message, *Response, error) {
+	return s.ListWithContext(context.Background(), accountID, inboxID)
+}
+
+// ListWithContext is like List but uses the given context for the request.
+func (s *MessagesService) ListWithContext(ctx context.Context, accountID, inboxID int) ([]*Message, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages", accountID, inboxID)
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var msg []*Message
-	res, err := s.client.Do(req, &msg)
+	res, err := s.client.Do(ctx, req, &msg)
 	if err != nil {
 		return nil, res, err
 	}
@@ -95,18 +240,95 @@ func (s *MessagesService) List(accountID, inboxID int) ([]*Message, *Response, e
 	return msg, res, nil
 }
 
+// ListUnread returns the messages of the inbox that have not been read yet.
+// The API has no filter for the read status, so the messages are filtered client-side.
+func (s *MessagesService) ListUnread(ctx context.Context, accountID, inboxID int) ([]*Message, *Response, error) {
+	list, res, err := s.ListWithContext(ctx, accountID, inboxID)
+	if err != nil {
+		return nil, res, err
+	}
+
+	unread := make([]*Message, 0, len(list))
+	for _, m := range list {
+		if !m.IsRead {
+			unread = append(unread, m)
+		}
+	}
+
+	return unread, res, nil
+}
+
+// CountUnread returns the number of messages of the inbox that have not been read yet.
+func (s *MessagesService) CountUnread(ctx context.Context, accountID, inboxID int) (int, *Response, error) {
+	unread, res, err := s.ListUnread(ctx, accountID, inboxID)
+	if err != nil {
+		return 0, res, err
+	}
+
+	return len(unread), res, nil
+}
+
+// GetBySubject returns the most recent message of the inbox with exactly the given subject,
+// or ErrNotFound if there is none.
+func (s *MessagesService) GetBySubject(
+	ctx context.Context,
+	accountID, inboxID int,
+	subject string,
+) (*Message, *Response, error) {
+	return s.find(ctx, accountID, inboxID, fmt.Sprintf("subject %q", subject), func(m *Message) bool {
+		return m.Subject == subject
+	})
+}
+
+// GetBySubjectContains returns the most recent message of the inbox whose subject contains
+// the given substring, or ErrNotFound if there is none.
+func (s *MessagesService) GetBySubjectContains(
+	ctx context.Context,
+	accountID, inboxID int,
+	substring string,
+) (*Message, *Response, error) {
+	return s.find(ctx, accountID, inboxID, fmt.Sprintf("subject containing %q", substring), func(m *Message) bool {
+		return strings.Contains(m.Subject, substring)
+	})
+}
+
+// find returns the first message of the inbox satisfying match. The API lists the most recent messages first.
+func (s *MessagesService) find(
+	ctx context.Context,
+	accountID, inboxID int,
+	desc string,
+	match func(*Message) bool,
+) (*Message, *Response, error) {
+	list, res, err := s.ListWithContext(ctx, accountID, inboxID)
+	if err != nil {
+		return nil, res, err
+	}
+	for _, m := range list {
+		if match(m) {
+			return m, res, nil
+		}
+	}
+
+	return nil, res, fmt.Errorf("message with %s: %w", desc, ErrNotFound)
+}
+
 // Get returns email message with its attributes by ID.
 //
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/c1708cf554d6e-show-email-message
 func (s *MessagesService) Get(accountID, inboxID, messageID int) (*Message, *Response, error) {
+	return s.GetWithContext(context.Background(), accountID, inboxID, messageID)
+}
+
+// GetWithContext is like Get but uses the given context for the request.
+func (s *MessagesService) GetWithContext(ctx context.Context, accountID, inboxID, messageID int) (*Message, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d", accountID, inboxID, messageID)
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var msg *Message
-	res, err := s.client.Do(req, &msg)
+	res, err := s.client.Do(ctx, req, &msg)
 	if err != nil {
 		return nil, res, err
 	}
@@ -124,19 +346,28 @@ type UpdateMessageRequest struct {
 func (s *MessagesService) Update(
 	accountID, inboxID, messageID int,
 	updateReq *UpdateMessageRequest,
+) (*Message, *Response, error) {
+	return s.UpdateWithContext(context.Background(), accountID, inboxID, messageID, updateReq)
+}
+
+// UpdateWithContext is like Update but uses the given context for the request.
+func (s *MessagesService) UpdateWithContext(
+	ctx context.Context,
+	accountID, inboxID, messageID int,
+	updateReq *UpdateMessageRequest,
 ) (*Message, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d", accountID, inboxID, messageID)
 	payload := struct {
 		Message *UpdateMessageRequest `json:"message"`
 	}{updateReq}
 
-	req, err := s.client.NewRequest(http.MethodPatch, u, payload)
+	req, err := s.client.NewRequest(ctx, http.MethodPatch, u, payload)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var msg *Message
-	res, err := s.client.Do(req, &msg)
+	res, err := s.client.Do(ctx, req, &msg)
 	if err != nil {
 		return nil, res, err
 	}
@@ -148,13 +379,18 @@ func (s *MessagesService) Update(
 //
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/53cf46462fba5-update-message
 func (s *MessagesService) Delete(accountID, inboxID, messageID int) (*Response, error) {
+	return s.DeleteWithContext(context.Background(), accountID, inboxID, messageID)
+}
+
+// DeleteWithContext is like Delete but uses the given context for the request.
+func (s *MessagesService) DeleteWithContext(ctx context.Context, accountID, inboxID, messageID int) (*Response, error) {
 	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d", accountID, inboxID, messageID)
-	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Do(req, nil)
+	return s.client.Do(ctx, req, nil)
 }
 
 type forwardRequest struct {
@@ -168,32 +404,46 @@ type forwardRequest struct {
 func (s *MessagesService) Forward(
 	accountID, inboxID, messageID int,
 	email string,
+) (*Response, error) {
+	return s.ForwardWithContext(context.Background(), accountID, inboxID, messageID, email)
+}
+
+// ForwardWithContext is like Forward but uses the given context for the request.
+func (s *MessagesService) ForwardWithContext(
+	ctx context.Context,
+	accountID, inboxID, messageID int,
+	email string,
 ) (*Response, error) {
 	if _, err := mail.ParseAddress(email); err != nil {
 		return nil, errors.New("forward 'email' is invalid")
 	}
 
 	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d/forward", accountID, inboxID, messageID)
-	req, err := s.client.NewRequest(http.MethodPost, u, &forwardRequest{Email: email})
+	req, err := s.client.NewRequest(ctx, http.MethodPost, u, &forwardRequest{Email: email})
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Do(req, nil)
+	return s.client.Do(ctx, req, nil)
 }
 
 // SpamReport returns a brief spam report by message ID.
 //
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/000f54556fc6e-get-message-spam-score
 func (s *MessagesService) SpamReport(accountID, inboxID, messageID int) (*SpamReport, *Response, error) {
+	return s.SpamReportWithContext(context.Background(), accountID, inboxID, messageID)
+}
+
+// SpamReportWithContext is like SpamReport but uses the given context for the request.
+func (s *MessagesService) SpamReportWithContext(ctx context.Context, accountID, inboxID, messageID int) (*SpamReport, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d/spam_report", accountID, inboxID, messageID)
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var report *SpamReport
-	res, err := s.client.Do(req, &report)
+	res, err := s.client.Do(ctx, req, &report)
 	if err != nil {
 		return nil, res, err
 	}
@@ -205,44 +455,377 @@ func (s *MessagesService) SpamReport(accountID, inboxID, messageID int) (*SpamRe
 //
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/53cf46462fba5-update-message
 func (s *MessagesService) AsRaw(accountID, inboxID, messageID int) (string, *Response, error) {
+	return s.AsRawWithContext(context.Background(), accountID, inboxID, messageID)
+}
+
+// AsRawWithContext is like AsRaw but uses the given context for the request.
+func (s *MessagesService) AsRawWithContext(ctx context.Context, accountID, inboxID, messageID int) (string, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d/body.raw", accountID, inboxID, messageID)
-	return s.makeRequest(u, http.MethodGet, "text/plain")
+	return s.makeRequestWithContext(ctx, u, http.MethodGet, "text/plain")
 }
 
 // AsText returns text email body, if it exists.
 //
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/53cf46462fba5-update-message
 func (s *MessagesService) AsText(accountID, inboxID, messageID int) (string, *Response, error) {
+	return s.AsTextWithContext(context.Background(), accountID, inboxID, messageID)
+}
+
+// AsTextWithContext is like AsText but uses the given context for the request.
+func (s *MessagesService) AsTextWithContext(ctx context.Context, accountID, inboxID, messageID int) (string, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d/body.txt", accountID, inboxID, messageID)
-	return s.makeRequest(u, http.MethodGet, "text/plain")
+	return s.makeRequestWithContext(ctx, u, http.MethodGet, "text/plain")
 }
 
 // AsHTML returns formatted HTML email body. Not applicable for plain text emails.
 //
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/53cf46462fba5-update-message
 func (s *MessagesService) AsHTML(accountID, inboxID, messageID int) (string, *Response, error) {
+	return s.AsHTMLWithContext(context.Background(), accountID, inboxID, messageID)
+}
+
+// AsHTMLWithContext is like AsHTML but uses the given context for the request.
+func (s *MessagesService) AsHTMLWithContext(ctx context.Context, accountID, inboxID, messageID int) (string, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d/body.html", accountID, inboxID, messageID)
-	return s.makeRequest(u, http.MethodGet, "text/html")
+	return s.makeRequestWithContext(ctx, u, http.MethodGet, "text/html")
 }
 
 // AsHTMLSource returns HTML source of email.
 //
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/53cf46462fba5-update-message
 func (s *MessagesService) AsHTMLSource(accountID, inboxID, messageID int) (string, *Response, error) {
+	return s.AsHTMLSourceWithContext(context.Background(), accountID, inboxID, messageID)
+}
+
+// AsHTMLSourceWithContext is like AsHTMLSource but uses the given context for the request.
+func (s *MessagesService) AsHTMLSourceWithContext(ctx context.Context, accountID, inboxID, messageID int) (string, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d/body.htmlsource", accountID, inboxID, messageID)
-	return s.makeRequest(u, http.MethodGet, "text/html")
+	return s.makeRequestWithContext(ctx, u, http.MethodGet, "text/html")
 }
 
 // AsEML returns email message in .eml format.
 //
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/53cf46462fba5-update-message
 func (s *MessagesService) AsEML(accountID, inboxID, messageID int) (string, *Response, error) {
+	return s.AsEMLWithContext(context.Background(), accountID, inboxID, messageID)
+}
+
+// AsEMLWithContext is like AsEML but uses the given context for the request.
+func (s *MessagesService) AsEMLWithContext(ctx context.Context, accountID, inboxID, messageID int) (string, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d/body.eml", accountID, inboxID, messageID)
-	return s.makeRequest(u, http.MethodGet, "message/rfc822")
+	return s.makeRequestWithContext(ctx, u, http.MethodGet, "message/rfc822")
+}
+
+// GetAsEML returns the raw bytes of the email message in .eml (message/rfc822) format,
+// suitable for processing with standard MIME libraries.
+//
+// See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/53cf46462fba5-update-message
+func (s *MessagesService) GetAsEML(ctx context.Context, accountID, inboxID, messageID int) ([]byte, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/inboxes/%d/messages/%d/body.eml", accountID, inboxID, messageID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "message/rfc822")
+
+	var data []byte
+	res, err := s.client.Do(ctx, req, &data)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return data, res, nil
+}
+
+// SaveAsEML downloads the email message in .eml format and writes it to the given file path.
+func (s *MessagesService) SaveAsEML(ctx context.Context, accountID, inboxID, messageID int, path string) error {
+	data, _, err := s.GetAsEML(ctx, accountID, inboxID, messageID)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// MailHeader is a single header field of an email message.
+type MailHeader struct {
+	Name  string
+	Value string
+}
+
+// MailHeaders is the list of header fields of an email message, in message order.
+type MailHeaders []MailHeader
+
+// Get returns the value of the first header with the given name, matched case-insensitively,
+// or an empty string if there is none.
+func (h MailHeaders) Get(name string) string {
+	for _, f := range h {
+		if strings.EqualFold(f.Name, name) {
+			return f.Value
+		}
+	}
+
+	return ""
+}
+
+// GetAll returns the values of all headers with the given name, matched case-insensitively,
+// e.g. every Received header.
+func (h MailHeaders) GetAll(name string) []string {
+	var values []string
+	for _, f := range h {
+		if strings.EqualFold(f.Name, name) {
+			values = append(values, f.Value)
+		}
+	}
+
+	return values
+}
+
+// GetHeaders downloads the message in .eml format and returns its header fields in message order.
+// Folded header lines are unfolded; values are not decoded.
+func (s *MessagesService) GetHeaders(ctx context.Context, accountID, inboxID, messageID int) (MailHeaders, *Response, error) {
+	data, res, err := s.GetAsEML(ctx, accountID, inboxID, messageID)
+	if err != nil {
+		return nil, res, err
+	}
+
+	headers, err := parseMailHeaders(data)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return headers, res, nil
+}
+
+// parseMailHeaders parses the header section of a raw message.
+func parseMailHeaders(data []byte) (MailHeaders, error) {
+	var headers MailHeaders
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		if line == "" {
+			break
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			if len(headers) == 0 {
+				return nil, fmt.Errorf("malformed header continuation line: %q", line)
+			}
+			last := &headers[len(headers)-1]
+			last.Value += " " + strings.TrimSpace(line)
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("malformed header line: %q", line)
+		}
+		headers = append(headers, MailHeader{Name: strings.TrimSpace(name), Value: strings.TrimSpace(value)})
+	}
+
+	return headers, nil
+}
+
+// MessageBody represents the body of an email message split into its parts.
+type MessageBody struct {
+	HTML         string
+	Text         string
+	AMP          string
+	InlineImages []InlineImageInfo
+
+	// Boundaries lists the multipart boundaries in the order they appear in the message.
+	Boundaries []string
+}
+
+// InlineImageInfo describes an inline image referenced from the HTML body.
+type InlineImageInfo struct {
+	ContentID    string
+	AttachmentID int
+	Filename     string
+	ContentType  string
+}
+
+// GetAttachmentContent returns the raw content and MIME type of the first message attachment
+// with the given filename, or ErrNotFound if the message has no such attachment.
+func (s *MessagesService) GetAttachmentContent(
+	ctx context.Context,
+	accountID, inboxID, messageID int,
+	filename string,
+) ([]byte, string, *Response, error) {
+	attachmentsService := &AttachmentsService{client: s.client}
+	attachments, res, err := attachmentsService.ListWithContext(ctx, accountID, inboxID, messageID)
+	if err != nil {
+		return nil, "", res, err
+	}
+
+	for _, a := range attachments {
+		if a.Filename != filename {
+			continue
+		}
+		r, res, err := attachmentsService.GetAsReader(ctx, accountID, inboxID, messageID, a.ID)
+		if err != nil {
+			return nil, "", res, err
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, "", res, err
+		}
+		return data, a.ContentType, res, nil
+	}
+
+	return nil, "", res, fmt.Errorf("attachment %q: %w", filename, ErrNotFound)
+}
+
+// GetBodyParts downloads the message in .eml format and splits it into the text, HTML and AMP bodies
+// and inline images. Inline images are matched with the message attachments to fill AttachmentID.
+func (s *MessagesService) GetBodyParts(
+	ctx context.Context,
+	accountID, inboxID, messageID int,
+) (*MessageBody, *Response, error) {
+	data, res, err := s.GetAsEML(ctx, accountID, inboxID, messageID)
+	if err != nil {
+		return nil, res, err
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, res, err
+	}
+	body := &MessageBody{}
+	if err := body.parsePart(textproto.MIMEHeader(msg.Header), msg.Body); err != nil {
+		return nil, res, err
+	}
+	if len(body.InlineImages) == 0 {
+		return body, res, nil
+	}
+
+	attachments, res, err := (&AttachmentsService{client: s.client}).ListWithContext(ctx, accountID, inboxID, messageID)
+	if err != nil {
+		return nil, res, err
+	}
+	for i, img := range body.InlineImages {
+		for _, a := range attachments {
+			if strings.Trim(a.ContentID, "<>") == img.ContentID {
+				body.InlineImages[i].AttachmentID = a.ID
+				break
+			}
+		}
+	}
+
+	return body, res, nil
+}
+
+// parsePart walks the MIME part recursively and collects the body parts.
+func (b *MessageBody) parsePart(header textproto.MIMEHeader, r io.Reader) error {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", nil
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		b.Boundaries = append(b.Boundaries, params["boundary"])
+		mr := multipart.NewReader(r, params["boundary"])
+		for {
+			p, err := mr.NextPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := b.parsePart(p.Header, p); err != nil {
+				return err
+			}
+		}
+	}
+
+	disposition, dispParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	contentID := strings.Trim(header.Get("Content-ID"), "<>")
+	if contentID != "" && strings.HasPrefix(mediaType, "image/") {
+		filename := dispParams["filename"]
+		if filename == "" {
+			filename = params["name"]
+		}
+		b.InlineImages = append(b.InlineImages, InlineImageInfo{
+			ContentID:   contentID,
+			Filename:    filename,
+			ContentType: mediaType,
+		})
+		return nil
+	}
+	if disposition == "attachment" {
+		return nil
+	}
+
+	// multipart.Reader already decodes quoted-printable parts, but a single-part message body is read as is.
+	switch strings.ToLower(header.Get("Content-Transfer-Encoding")) {
+	case "base64":
+		r = base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		r = quotedprintable.NewReader(r)
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	switch mediaType {
+	case "text/plain":
+		b.Text += string(content)
+	case "text/html":
+		b.HTML += string(content)
+	case "text/x-amp-html":
+		b.AMP += string(content)
+	}
+
+	return nil
+}
+
+// defaultWaitInterval is the polling interval used by WaitForMessage when none is given.
+const defaultWaitInterval = time.Second
+
+// WaitForMessage polls the inbox every interval until a message satisfying match arrives
+// or the context is done, in which case the context error is returned.
+func (s *MessagesService) WaitForMessage(
+	ctx context.Context,
+	accountID, inboxID int,
+	interval time.Duration,
+	match func(*Message) bool,
+) (*Message, *Response, error) {
+	if match == nil {
+		return nil, nil, errors.New("'match' is required")
+	}
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		msgs, res, err := s.ListWithContext(ctx, accountID, inboxID)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, res, ctxErr
+		}
+		if err != nil {
+			return nil, res, err
+		}
+		for _, m := range msgs {
+			if match(m) {
+				return m, res, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, res, ctx.Err()
+		case <-ticker.C:
+		}
+	}
 }
 
-func (s *MessagesService) makeRequest(endpoint, httpMethod string, acceptHeader string) (string, *Response, error) {
-	req, err := s.client.NewRequest(httpMethod, endpoint, nil)
+func (s *MessagesService) makeRequestWithContext(
+	ctx context.Context,
+	endpoint, httpMethod string,
+	acceptHeader string,
+) (string, *Response, error) {
+	req, err := s.client.NewRequest(ctx, httpMethod, endpoint, nil)
 	if err != nil {
 		return "", nil, err
 	}
@@ -250,7 +833,7 @@ func (s *MessagesService) makeRequest(endpoint, httpMethod string, acceptHeader
 	req.Header.Set("Accept", acceptHeader)
 
 	var respStr string
-	res, err := s.client.Do(req, &respStr)
+	res, err := s.client.Do(ctx, req, &respStr)
 	if err != nil {
 		return "", res, err
 	}
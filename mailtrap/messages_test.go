@@ -1,9 +1,14 @@
 package mailtrap
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -24,6 +29,12 @@ func TestMessagesService_Marshal(t *testing.T) {
 		"to_name": "Mary",
 		"email_size": 30,
 		"is_read": false,
+		"html_body": "<p>Hello</p>",
+		"text_body": "Hello",
+		"spam_score": 1.5,
+		"is_spam": false,
+		"bounce_category": "",
+		"raw_size": 300,
 		"created_at": "2023-02-14T19:29:59.295Z",
 		"updated_at": "2023-02-14T19:29:59.295Z",
 		"html_body_size": 200,
@@ -46,6 +57,69 @@ func TestMessagesService_Marshal(t *testing.T) {
 	testJSONMarshal(t, u, want)
 }
 
+func TestMessage_Unmarshal(t *testing.T) {
+	data := `{
+		"id": 1,
+		"inbox_id": 2,
+		"subject": "Welcome",
+		"sent_at": "2023-02-14T19:29:59Z",
+		"from_email": "john@example.com",
+		"from_name": "John",
+		"to_email": "mary@example.com",
+		"html_body": "<p>Hi</p>",
+		"text_body": "Hi",
+		"is_read": true,
+		"spam_score": 2.3,
+		"is_spam": true,
+		"bounce_category": "hard",
+		"raw_size": 512,
+		"human_size": "512 Bytes",
+		"html_body_size": 9,
+		"text_body_size": 2,
+		"created_at": "2023-02-14T19:30:00Z",
+		"updated_at": "2023-02-14T19:30:01Z"
+	}`
+
+	var got Message
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	sentAt := time.Date(2023, 2, 14, 19, 29, 59, 0, time.UTC)
+	expected := Message{
+		ID:             1,
+		InboxID:        2,
+		Subject:        "Welcome",
+		SentAt:         sentAt,
+		FromEmail:      "john@example.com",
+		FromName:       "John",
+		ToEmail:        "mary@example.com",
+		HTMLBody:       "<p>Hi</p>",
+		TextBody:       "Hi",
+		IsRead:         true,
+		SpamScore:      2.3,
+		IsSpam:         true,
+		BounceCategory: "hard",
+		RawSize:        512,
+		HumanSize:      "512 Bytes",
+		HTMLBodySize:   9,
+		TextBodySize:   2,
+		CreatedAt:      sentAt.Add(time.Second),
+		UpdatedAt:      sentAt.Add(2 * time.Second),
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("json.Unmarshal returned %+v, expected %+v", got, expected)
+	}
+
+	if st := got.SentTime(); !st.Equal(sentAt) {
+		t.Errorf("Message.SentTime() = %v, expected %v", st, sentAt)
+	}
+	got.SentAt = time.Time{}
+	if st := got.SentTime(); !st.Equal(got.CreatedAt) {
+		t.Errorf("Message.SentTime() without sent_at = %v, expected %v", st, got.CreatedAt)
+	}
+}
+
 func TestMessagesService_List(t *testing.T) {
 	client, mux, teardown := setupTestingClient()
 	defer teardown()
@@ -81,6 +155,94 @@ func TestMessagesService_List(t *testing.T) {
 	})
 }
 
+func TestMessagesService_ListUnread(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":1,"is_read":true},{"id":2,"is_read":false},{"id":3,"is_read":false}]`)
+	})
+
+	messages, _, err := client.Messages.ListUnread(context.Background(), 1, 2)
+	if err != nil {
+		t.Errorf("Messages.ListUnread returned error: %v", err)
+	}
+
+	expected := []*Message{{ID: 2}, {ID: 3}}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Errorf("Messages.ListUnread returned %+v, expected %+v", messages, expected)
+	}
+
+	count, _, err := client.Messages.CountUnread(context.Background(), 1, 2)
+	if err != nil {
+		t.Errorf("Messages.CountUnread returned error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Messages.CountUnread returned %d, expected 2", count)
+	}
+
+	testBadPathParams(t, "Messages.CountUnread", func() error {
+		_, _, err = client.Messages.CountUnread(context.Background(), -1, -20)
+		return err
+	})
+}
+
+func TestMessagesService_GetBySubject(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":3,"subject":"Your order #2 has shipped"},{"id":2,"subject":"Welcome"},`+
+			`{"id":1,"subject":"Your order #1 has shipped"}]`)
+	})
+
+	tests := []struct {
+		name   string
+		get    func() (*Message, *Response, error)
+		wantID int
+	}{
+		{"exact", func() (*Message, *Response, error) {
+			return client.Messages.GetBySubject(context.Background(), 1, 2, "Welcome")
+		}, 2},
+		{"contains", func() (*Message, *Response, error) {
+			return client.Messages.GetBySubjectContains(context.Background(), 1, 2, "#1")
+		}, 1},
+		{"contains multiple", func() (*Message, *Response, error) {
+			return client.Messages.GetBySubjectContains(context.Background(), 1, 2, "has shipped")
+		}, 3},
+		{"exact no partial", func() (*Message, *Response, error) {
+			return client.Messages.GetBySubject(context.Background(), 1, 2, "Welcome!")
+		}, 0},
+		{"contains no match", func() (*Message, *Response, error) {
+			return client.Messages.GetBySubjectContains(context.Background(), 1, 2, "Invoice")
+		}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, _, err := tt.get()
+			if tt.wantID == 0 {
+				if !errors.Is(err, ErrNotFound) || msg != nil {
+					t.Errorf("returned %+v, %v, expected %v", msg, err, ErrNotFound)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+			if msg.ID != tt.wantID {
+				t.Errorf("returned message %d, expected %d", msg.ID, tt.wantID)
+			}
+		})
+	}
+
+	testBadPathParams(t, "Messages.GetBySubject", func() error {
+		_, _, err := client.Messages.GetBySubject(context.Background(), -1, -20, "Welcome")
+		return err
+	})
+}
+
 func TestMessagesService_Get(t *testing.T) {
 	client, mux, teardown := setupTestingClient()
 	defer teardown()
@@ -238,6 +400,40 @@ func TestMessagesService_SpamReport(t *testing.T) {
 	})
 }
 
+func TestSpamReport_Rules(t *testing.T) {
+	var report SpamReport
+	data := `{"report":{"Score":2.9,"Details":[
+		{"Pts":"0.8","RuleName":"HTML_MESSAGE","Description":"HTML included in message"},
+		null,
+		{"Pts":"-1.0","RuleName":"DKIM_VALID","Description":"Message has a valid DKIM signature"},
+		{"Pts":"2.1","RuleName":"RDNS_NONE","Description":"Delivered to internal network by a host with no rDNS"},
+		"unexpected"
+	]}}`
+	if err := json.Unmarshal([]byte(data), &report); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	expected := []SpamRule{
+		{Name: "RDNS_NONE", Score: 2.1, Description: "Delivered to internal network by a host with no rDNS"},
+		{Name: "HTML_MESSAGE", Score: 0.8, Description: "HTML included in message"},
+		{Name: "DKIM_VALID", Score: -1.0, Description: "Message has a valid DKIM signature"},
+	}
+	if rules := report.RulesByScore(); !reflect.DeepEqual(rules, expected) {
+		t.Errorf("SpamReport.RulesByScore() = %+v, expected %+v", rules, expected)
+	}
+	if total := report.TotalScore(); math.Abs(total-2.9) > 1e-9 {
+		t.Errorf("SpamReport.TotalScore() = %v, expected 2.9", total)
+	}
+
+	var empty SpamReport
+	if rules := empty.RulesByScore(); rules != nil {
+		t.Errorf("SpamReport.RulesByScore() = %+v, expected nil", rules)
+	}
+	if total := empty.TotalScore(); total != 0 {
+		t.Errorf("SpamReport.TotalScore() = %v, expected 0", total)
+	}
+}
+
 func TestMessagesService_AsRaw(t *testing.T) {
 	client, mux, teardown := setupTestingClient()
 	defer teardown()
@@ -455,6 +651,320 @@ func TestMessagesService_AsEml(t *testing.T) {
 	}
 }
 
+func TestMessagesService_GetAsEML(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	emlBody := "From: ches@example.com\r\nTo: jd@example.com\r\nSubject: Hello\r\n\r\nHello, world!\r\n"
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/body.eml", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", "message/rfc822")
+		fmt.Fprint(w, emlBody)
+	})
+
+	data, _, err := client.Messages.GetAsEML(context.Background(), 1, 2, 3)
+	if err != nil {
+		t.Errorf("Messages.GetAsEML returned error: %v", err)
+	}
+	if string(data) != emlBody {
+		t.Errorf("Messages.GetAsEML returned %q, expected %q", data, emlBody)
+	}
+
+	path := filepath.Join(t.TempDir(), "message.eml")
+	if err := client.Messages.SaveAsEML(context.Background(), 1, 2, 3, path); err != nil {
+		t.Fatalf("Messages.SaveAsEML returned error: %v", err)
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unable to read saved file: %v", err)
+	}
+	if string(saved) != emlBody {
+		t.Errorf("Messages.SaveAsEML wrote %q, expected %q", saved, emlBody)
+	}
+
+	testBadPathParams(t, "Messages.SaveAsEML", func() error {
+		return client.Messages.SaveAsEML(context.Background(), -1, -2, -3, filepath.Join(t.TempDir(), "bad.eml"))
+	})
+
+	testNewRequestAndDoFail(t, "Messages.GetAsEML", &client.client, func() (*Response, error) {
+		data, resp, err := client.Messages.GetAsEML(context.Background(), 1, 2, 3)
+		if data != nil {
+			t.Errorf("Messages.GetAsEML client.BaseURL.Host=%v data=%#v, want nil", client.baseURL.Host, data)
+		}
+		return resp, err
+	})
+}
+
+func TestMessagesService_WaitForMessage(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/accounts/1/inboxes/2/messages", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		calls++
+		messages := []*Message{messageMock(1)}
+		if calls > 2 {
+			messages = append(messages, &Message{ID: 2, Subject: "Expected"})
+		}
+		resp, _ := json.Marshal(messages)
+		fmt.Fprint(w, string(resp))
+	})
+
+	msg, _, err := client.Messages.WaitForMessage(context.Background(), 1, 2, time.Millisecond, func(m *Message) bool {
+		return m.Subject == "Expected"
+	})
+	if err != nil {
+		t.Fatalf("Messages.WaitForMessage returned error: %v", err)
+	}
+	if msg.ID != 2 {
+		t.Errorf("Messages.WaitForMessage returned message %d, expected 2", msg.ID)
+	}
+	if calls != 3 {
+		t.Errorf("Messages.WaitForMessage polled %d times, expected 3", calls)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, _, err = client.Messages.WaitForMessage(ctx, 1, 2, time.Millisecond, func(m *Message) bool {
+		return false
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Messages.WaitForMessage returned error %v, expected %v", err, context.DeadlineExceeded)
+	}
+
+	testBadPathParams(t, "Messages.WaitForMessage", func() error {
+		_, _, err := client.Messages.WaitForMessage(context.Background(), 1, 2, time.Millisecond, nil)
+		return err
+	})
+}
+
+func TestMessagesService_GetBodyParts(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	eml := "From: ches@example.com\r\n" +
+		"To: jd@example.com\r\n" +
+		"Subject: Hello\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/related; boundary=\"outer\"\r\n" +
+		"\r\n" +
+		"--outer\r\n" +
+		"Content-Type: multipart/alternative; boundary=\"inner\"\r\n" +
+		"\r\n" +
+		"--inner\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n" +
+		"\r\n" +
+		"Hello, w=C3=B6rld!\r\n" +
+		"--inner\r\n" +
+		"Content-Type: text/x-amp-html; charset=utf-8\r\n" +
+		"\r\n" +
+		"<html amp4email></html>\r\n" +
+		"--inner\r\n" +
+		"Content-Type: text/html; charset=utf-8\r\n" +
+		"Content-Transfer-Encoding: base64\r\n" +
+		"\r\n" +
+		"PHA+SGVsbG8sIDxpbWcgc3JjPSJjaWQ6bG9nbyI+PC9wPg==\r\n" +
+		"--inner--\r\n" +
+		"--outer\r\n" +
+		"Content-Type: image/png; name=\"logo.png\"\r\n" +
+		"Content-Disposition: inline; filename=\"logo.png\"\r\n" +
+		"Content-Transfer-Encoding: base64\r\n" +
+		"Content-ID: <logo>\r\n" +
+		"\r\n" +
+		"iVBORw0KGgo=\r\n" +
+		"--outer--\r\n"
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/body.eml", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "Accept", "message/rfc822")
+		fmt.Fprint(w, eml)
+	})
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/attachments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":7,"filename":"report.pdf"},{"id":8,"filename":"logo.png","content_id":"<logo>"}]`)
+	})
+
+	body, _, err := client.Messages.GetBodyParts(context.Background(), 1, 2, 3)
+	if err != nil {
+		t.Fatalf("Messages.GetBodyParts returned error: %v", err)
+	}
+
+	expected := &MessageBody{
+		Text: "Hello, wörld!",
+		HTML: `<p>Hello, <img src="cid:logo"></p>`,
+		AMP:  "<html amp4email></html>",
+		InlineImages: []InlineImageInfo{
+			{ContentID: "logo", AttachmentID: 8, Filename: "logo.png", ContentType: "image/png"},
+		},
+		Boundaries: []string{"outer", "inner"},
+	}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("Messages.GetBodyParts returned %+v, expected %+v", body, expected)
+	}
+}
+
+func TestMessagesService_GetBodyParts_quotedPrintable(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	eml := "From: ches@example.com\r\n" +
+		"To: jd@example.com\r\n" +
+		"Subject: Hello\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n" +
+		"\r\n" +
+		"Hello, w=C3=B6rld! This line is long enough to be wrapped with a soft line =\r\n" +
+		"break.\r\n"
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/body.eml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, eml)
+	})
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/attachments", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Messages.GetBodyParts listed attachments of a message without inline images")
+	})
+
+	body, _, err := client.Messages.GetBodyParts(context.Background(), 1, 2, 3)
+	if err != nil {
+		t.Fatalf("Messages.GetBodyParts returned error: %v", err)
+	}
+
+	expected := &MessageBody{Text: "Hello, wörld! This line is long enough to be wrapped with a soft line break.\r\n"}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("Messages.GetBodyParts returned %+v, expected %+v", body, expected)
+	}
+}
+
+func TestMessagesService_GetAttachmentContent(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/attachments", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[
+			{"id":4,"filename":"logo.png","content_type":"image/png"},
+			{"id":5,"filename":"report.csv","content_type":"text/csv"},
+			{"id":6,"filename":"report.csv","content_type":"text/plain"}
+		]`)
+	})
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/attachments/4/download", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "png")
+	})
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/attachments/5/download", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, "id,name\n1,test\n")
+	})
+
+	content, contentType, _, err := client.Messages.GetAttachmentContent(context.Background(), 1, 2, 3, "report.csv")
+	if err != nil {
+		t.Fatalf("Messages.GetAttachmentContent returned error: %v", err)
+	}
+	if want := "id,name\n1,test\n"; string(content) != want {
+		t.Errorf("Messages.GetAttachmentContent returned content %q, expected %q", content, want)
+	}
+	if contentType != "text/csv" {
+		t.Errorf("Messages.GetAttachmentContent returned content type %q, expected %q", contentType, "text/csv")
+	}
+
+	_, _, _, err = client.Messages.GetAttachmentContent(context.Background(), 1, 2, 3, "missing.pdf")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Messages.GetAttachmentContent returned error %v, expected %v", err, ErrNotFound)
+	}
+
+	testNewRequestAndDoFail(t, "Messages.GetAttachmentContent", &client.client, func() (*Response, error) {
+		content, _, resp, err := client.Messages.GetAttachmentContent(context.Background(), 1, 2, 3, "report.csv")
+		if content != nil {
+			t.Errorf("Messages.GetAttachmentContent client.BaseURL.Host=%v content=%q, want nil", client.baseURL.Host, content)
+		}
+		return resp, err
+	})
+}
+
+func TestMessagesService_GetHeaders(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	eml := "Received: from mx2.example.com\r\n" +
+		"\tby mx.mailtrap.io; Tue, 14 Feb 2023 19:29:59 +0000\r\n" +
+		"Received: from localhost by mx2.example.com\r\n" +
+		"DKIM-Signature: v=1; a=rsa-sha256; d=example.com\r\n" +
+		"Authentication-Results: mx.mailtrap.io; dkim=pass\r\n" +
+		"Subject: Hello\r\n" +
+		"\r\n" +
+		"Body-Like: not a header\r\n"
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/body.eml", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "Accept", "message/rfc822")
+		fmt.Fprint(w, eml)
+	})
+
+	headers, _, err := client.Messages.GetHeaders(context.Background(), 1, 2, 3)
+	if err != nil {
+		t.Fatalf("Messages.GetHeaders returned error: %v", err)
+	}
+
+	expected := MailHeaders{
+		{Name: "Received", Value: "from mx2.example.com by mx.mailtrap.io; Tue, 14 Feb 2023 19:29:59 +0000"},
+		{Name: "Received", Value: "from localhost by mx2.example.com"},
+		{Name: "DKIM-Signature", Value: "v=1; a=rsa-sha256; d=example.com"},
+		{Name: "Authentication-Results", Value: "mx.mailtrap.io; dkim=pass"},
+		{Name: "Subject", Value: "Hello"},
+	}
+	if !reflect.DeepEqual(headers, expected) {
+		t.Errorf("Messages.GetHeaders returned %+v, expected %+v", headers, expected)
+	}
+
+	if got := headers.Get("dkim-signature"); got != "v=1; a=rsa-sha256; d=example.com" {
+		t.Errorf("MailHeaders.Get(dkim-signature) = %q", got)
+	}
+	if got := headers.Get("X-Missing"); got != "" {
+		t.Errorf("MailHeaders.Get(X-Missing) = %q, expected empty", got)
+	}
+	wantReceived := []string{expected[0].Value, expected[1].Value}
+	if got := headers.GetAll("RECEIVED"); !reflect.DeepEqual(got, wantReceived) {
+		t.Errorf("MailHeaders.GetAll(RECEIVED) = %q, expected %q", got, wantReceived)
+	}
+	if got := headers.GetAll("Body-Like"); got != nil {
+		t.Errorf("MailHeaders.GetAll(Body-Like) = %q, expected nil", got)
+	}
+
+	testNewRequestAndDoFail(t, "Messages.GetHeaders", &client.client, func() (*Response, error) {
+		headers, resp, err := client.Messages.GetHeaders(context.Background(), 1, 2, 3)
+		if headers != nil {
+			t.Errorf("Messages.GetHeaders client.BaseURL.Host=%v headers=%#v, want nil", client.baseURL.Host, headers)
+		}
+		return resp, err
+	})
+}
+
+func TestMessagesService_GetBodyParts_textOnly(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages/3/body.eml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "From: ches@example.com\r\nSubject: Hello\r\nContent-Type: text/plain\r\n\r\nJust text.")
+	})
+
+	body, _, err := client.Messages.GetBodyParts(context.Background(), 1, 2, 3)
+	if err != nil {
+		t.Fatalf("Messages.GetBodyParts returned error: %v", err)
+	}
+
+	expected := &MessageBody{Text: "Just text."}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("Messages.GetBodyParts returned %+v, expected %+v", body, expected)
+	}
+
+	testNewRequestAndDoFail(t, "Messages.GetBodyParts", &client.client, func() (*Response, error) {
+		body, resp, err := client.Messages.GetBodyParts(context.Background(), 1, 2, 3)
+		if body != nil {
+			t.Errorf("Messages.GetBodyParts client.BaseURL.Host=%v body=%#v, want nil", client.baseURL.Host, body)
+		}
+		return resp, err
+	})
+}
+
 func messageMock(ID int) *Message {
 	var smtp = new(MessageSMTPInfo)
 	smtp.Ok = true
@@ -474,6 +984,10 @@ func messageMock(ID int) *Message {
 		ToName:               "Mary",
 		EmailSize:            30,
 		IsRead:               false,
+		HTMLBody:             "<p>Hello</p>",
+		TextBody:             "Hello",
+		SpamScore:            1.5,
+		RawSize:              300,
 		CreatedAt:            datetime,
 		UpdatedAt:            datetime,
 		HTMLBodySize:         200,
@@ -0,0 +1,203 @@
+package mailtrap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// MIMEOption configures how ToMIMEMessage serializes the request.
+type MIMEOption func(*mimeConfig)
+
+type mimeConfig struct {
+	// Multipart boundary used instead of a random one.
+	boundary string
+}
+
+// WithFixedBoundary makes ToMIMEMessage use the given multipart boundary instead of a random one,
+// so that the serialized messages can be compared in tests. The nested multipart/alternative part
+// uses the boundary with "-alt" appended, so the boundary must be at most 66 characters long.
+// It requires a testing.TB to keep deterministic boundaries out of production code.
+func WithFixedBoundary(tb testing.TB, boundary string) MIMEOption {
+	tb.Helper()
+	return func(c *mimeConfig) {
+		c.boundary = boundary
+	}
+}
+
+// maxBoundaryLength is the maximum length of a multipart boundary (RFC 2046).
+const maxBoundaryLength = 70
+
+// alternativeBoundarySuffix is appended to a fixed boundary for the nested multipart/alternative part.
+const alternativeBoundarySuffix = "-alt"
+
+// ToMIMEMessage serializes the request as a MIME message (RFC 5322) with a multipart/mixed body
+// containing the text and HTML alternatives followed by the attachments.
+// Bcc recipients are not included in the headers.
+func (r *SendEmailRequest) ToMIMEMessage(opts ...MIMEOption) ([]byte, error) {
+	cfg := &mimeConfig{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+
+	var buf bytes.Buffer
+	mixed := multipart.NewWriter(&buf)
+	alternative := multipart.NewWriter(nil)
+	if cfg.boundary != "" {
+		if len(cfg.boundary)+len(alternativeBoundarySuffix) > maxBoundaryLength {
+			return nil, fmt.Errorf("boundary must be at most %d characters", maxBoundaryLength-len(alternativeBoundarySuffix))
+		}
+		if err := mixed.SetBoundary(cfg.boundary); err != nil {
+			return nil, err
+		}
+		if err := alternative.SetBoundary(cfg.boundary + alternativeBoundarySuffix); err != nil {
+			return nil, err
+		}
+	}
+
+	writeMIMEHeaders(&buf, r, mixed.Boundary())
+
+	if err := writeAlternativePart(mixed, alternative.Boundary(), r.Text, r.HTML); err != nil {
+		return nil, err
+	}
+	for _, a := range r.Attachments {
+		if err := writeAttachmentPart(mixed, a); err != nil {
+			return nil, err
+		}
+	}
+	if err := mixed.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeMIMEHeaders writes the top-level message headers in a fixed order.
+func writeMIMEHeaders(buf *bytes.Buffer, r *SendEmailRequest, boundary string) {
+	fmt.Fprintf(buf, "From: %s\r\n", formatMIMEAddress(r.From))
+	if len(r.To) > 0 {
+		fmt.Fprintf(buf, "To: %s\r\n", formatMIMEAddresses(r.To))
+	}
+	if len(r.Cc) > 0 {
+		fmt.Fprintf(buf, "Cc: %s\r\n", formatMIMEAddresses(r.Cc))
+	}
+	fmt.Fprintf(buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", r.Subject))
+
+	names := make([]string, 0, len(r.Headers))
+	for name := range r.Headers {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(buf, "%s: %s\r\n", name, mime.QEncoding.Encode("utf-8", r.Headers[HeaderName(name)]))
+	}
+
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", boundary)
+}
+
+// writeAlternativePart writes the text and HTML bodies as a nested multipart/alternative part.
+func writeAlternativePart(mixed *multipart.Writer, boundary, text, html string) error {
+	if text == "" && html == "" {
+		return nil
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", boundary))
+	w, err := mixed.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	alternative := multipart.NewWriter(w)
+	if err := alternative.SetBoundary(boundary); err != nil {
+		return err
+	}
+	for _, body := range []struct{ contentType, content string }{
+		{"text/plain", text},
+		{"text/html", html},
+	} {
+		if body.content == "" {
+			continue
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", body.contentType+"; charset=utf-8")
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		pw, err := alternative.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		qw := quotedprintable.NewWriter(pw)
+		if _, err := qw.Write([]byte(body.content)); err != nil {
+			return err
+		}
+		if err := qw.Close(); err != nil {
+			return err
+		}
+	}
+
+	return alternative.Close()
+}
+
+// writeAttachmentPart writes the base64-encoded attachment as a part wrapped at 76 characters.
+func writeAttachmentPart(mixed *multipart.Writer, a EmailAttachment) error {
+	if a.Filename == "" {
+		return errors.New("'filename' is required in attachment")
+	}
+
+	contentType := a.AttachType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	disposition := a.Disposition
+	if disposition == "" {
+		disposition = "attachment"
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", mime.FormatMediaType(contentType, map[string]string{"name": a.Filename}))
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": a.Filename}))
+	header.Set("Content-Transfer-Encoding", "base64")
+	if a.ContentID != "" {
+		header.Set("Content-ID", a.ContentID)
+	}
+	w, err := mixed.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	const lineLength = 76
+	content := strings.Join(strings.Fields(a.Content), "")
+	for len(content) > lineLength {
+		if _, err := fmt.Fprintf(w, "%s\r\n", content[:lineLength]); err != nil {
+			return err
+		}
+		content = content[lineLength:]
+	}
+	_, err = fmt.Fprintf(w, "%s\r\n", content)
+
+	return err
+}
+
+func formatMIMEAddress(a EmailAddress) string {
+	return (&mail.Address{Name: a.Name, Address: a.Email}).String()
+}
+
+func formatMIMEAddresses(addrs []EmailAddress) string {
+	formatted := make([]string, 0, len(addrs))
+	for _, a := range addrs {
+		formatted = append(formatted, formatMIMEAddress(a))
+	}
+
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,89 @@
+package mailtrap
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestSendEmailRequest_ToMIMEMessage(t *testing.T) {
+	req := emailRequestMock()
+	req.HTML = "<p>Congratulations on your order no.123</p>"
+
+	data, err := req.ToMIMEMessage()
+	if err != nil {
+		t.Fatalf("ToMIMEMessage returned error: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Unable to parse MIME message: %v", err)
+	}
+	if got := msg.Header.Get("Subject"); got != req.Subject {
+		t.Errorf("Subject header = %q, want %q", got, req.Subject)
+	}
+	if got := msg.Header.Get("X-Message-Source"); got != "mail.example.com" {
+		t.Errorf("X-Message-Source header = %q, want %q", got, "mail.example.com")
+	}
+	if got := msg.Header.Get("Bcc"); got != "" {
+		t.Errorf("Bcc header = %q, want empty", got)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("Content-Type = %q, %v, want multipart/mixed", mediaType, err)
+	}
+
+	var parts []string
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Unable to read part: %v", err)
+		}
+		parts = append(parts, p.Header.Get("Content-Type"))
+	}
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], "multipart/alternative") || !strings.HasPrefix(parts[1], "text/html") {
+		t.Errorf("Parts = %q, want alternative body and text/html attachment", parts)
+	}
+}
+
+func TestSendEmailRequest_ToMIMEMessage_fixedBoundary(t *testing.T) {
+	req := emailRequestMock()
+
+	first, err := req.ToMIMEMessage(WithFixedBoundary(t, "fixed-boundary"))
+	if err != nil {
+		t.Fatalf("ToMIMEMessage returned error: %v", err)
+	}
+	second, err := req.ToMIMEMessage(WithFixedBoundary(t, "fixed-boundary"))
+	if err != nil {
+		t.Fatalf("ToMIMEMessage returned error: %v", err)
+	}
+
+	if !bytes.Equal(first, second) {
+		t.Errorf("ToMIMEMessage with fixed boundary is not deterministic:\n%s\n---\n%s", first, second)
+	}
+	for _, want := range []string{`boundary="fixed-boundary"`, "--fixed-boundary\r\n", "--fixed-boundary--", `boundary="fixed-boundary-alt"`} {
+		if !bytes.Contains(first, []byte(want)) {
+			t.Errorf("ToMIMEMessage output does not contain %q", want)
+		}
+	}
+
+	if _, err := req.ToMIMEMessage(WithFixedBoundary(t, "bad boundary\n")); err == nil {
+		t.Error("ToMIMEMessage with invalid boundary, err = nil, want error")
+	}
+
+	if _, err := req.ToMIMEMessage(WithFixedBoundary(t, strings.Repeat("b", 66))); err != nil {
+		t.Errorf("ToMIMEMessage with 66 character boundary returned error: %v", err)
+	}
+	if _, err := req.ToMIMEMessage(WithFixedBoundary(t, strings.Repeat("b", 67))); err == nil {
+		t.Error("ToMIMEMessage with 67 character boundary, err = nil, want error")
+	}
+}
@@ -0,0 +1,268 @@
+package mailtrap
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Option configures a Mailtrap client.
+type Option func(*client) error
+
+// WithBaseURL overrides the base URL of the API, e.g. to point the client at a proxy or a test server.
+// The URL is used as is; the API path prefix is not appended.
+func WithBaseURL(baseURL string) Option {
+	return func(c *client) error {
+		u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+		if err != nil {
+			return err
+		}
+		c.baseURL = *u
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to communicate with the API, e.g. to configure proxies or TLS.
+// The client is used as is; options such as WithTimeout and WithTransport apply to a copy of it.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *client) error {
+		if hc == nil {
+			return errors.New("HTTP client must not be nil")
+		}
+		c.httpClient = hc
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of the HTTP client, including connecting and reading the response.
+// It defaults to 30 seconds for the sending clients and no timeout for the testing client.
+func WithTimeout(d time.Duration) Option {
+	return func(c *client) error {
+		if d <= 0 {
+			return errors.New("timeout must be positive")
+		}
+		c.timeout = d
+		return nil
+	}
+}
+
+// WithUserAgent overrides the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(c *client) error {
+		if ua == "" {
+			return errors.New("user agent must not be empty")
+		}
+		c.userAgent = ua
+		return nil
+	}
+}
+
+// WithAutoHTMLFromText enables generating the HTML body from the text body
+// when a request only sets Text. The text is escaped and wrapped in a <pre> block.
+func WithAutoHTMLFromText() Option {
+	return func(c *client) error {
+		c.autoHTMLFromText = true
+		return nil
+	}
+}
+
+// WithAutoTextFallback enables generating the text body from the HTML body with StripHTMLForText
+// when a request only sets HTML. It applies to every request sent by the client.
+func WithAutoTextFallback() Option {
+	return func(c *client) error {
+		c.autoTextFallback = true
+		return nil
+	}
+}
+
+// WithStrictValidation turns selected Lint warnings into validation errors,
+// e.g. sending to a recipient on the same domain as the from address.
+func WithStrictValidation() Option {
+	return func(c *client) error {
+		c.strictValidation = true
+		return nil
+	}
+}
+
+// WithVerifiedDomains restricts the from address to the given verified sender domains and their subdomains.
+// Sending from any other domain fails validation with ErrUnverifiedSenderDomain.
+func WithVerifiedDomains(domains []string) Option {
+	return func(c *client) error {
+		c.verifiedDomains = nil
+		for _, d := range domains {
+			c.verifiedDomains = append(c.verifiedDomains, strings.ToLower(strings.TrimSpace(d)))
+		}
+		return nil
+	}
+}
+
+// WithEagerVerify makes NewTestingClient call GET /api/accounts and return an error
+// if the API key is invalid or the API is unreachable.
+// This costs an extra round-trip on startup; without it the client connects lazily
+// and such problems surface on the first API call.
+func WithEagerVerify() Option {
+	return func(c *client) error {
+		c.eagerVerify = true
+		return nil
+	}
+}
+
+// WithSafeHeaders makes validation reject header names that do not match X-[A-Za-z0-9-]+,
+// preventing standard headers from being set as custom headers.
+func WithSafeHeaders() Option {
+	return func(c *client) error {
+		c.safeHeaders = true
+		return nil
+	}
+}
+
+// WithRequestPreProcessor registers a function that is called with every request
+// just before NewRequest returns it, e.g. to sign requests.
+// Multiple pre-processors run in registration order; the first error aborts
+// the chain and is returned by NewRequest.
+func WithRequestPreProcessor(fn func(req *http.Request) error) Option {
+	return func(c *client) error {
+		if fn != nil {
+			c.requestPreProcessors = append(c.requestPreProcessors, fn)
+		}
+		return nil
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of requests the client has in flight at once,
+// across all goroutines using it. Requests over the limit wait for a free slot or for their
+// context to be done. Zero, the default, means no limit.
+func WithMaxConcurrentRequests(n int) Option {
+	return func(c *client) error {
+		if n < 0 {
+			return errors.New("max concurrent requests must not be negative")
+		}
+		c.requestSem = nil
+		if n > 0 {
+			c.requestSem = make(chan struct{}, n)
+		}
+		return nil
+	}
+}
+
+// WithRateLimit limits the client to requestsPerSecond requests on average, with bursts of up to burst requests.
+// Do blocks until the request is allowed or the request context is done.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *client) error {
+		if requestsPerSecond <= 0 {
+			return errors.New("requests per second must be positive")
+		}
+		if burst < 1 {
+			return errors.New("burst must be at least 1")
+		}
+		c.rateLimiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+		return nil
+	}
+}
+
+// WithRetry retries requests that failed with a 429, 500, 502, 503 or 504 response up to maxAttempts
+// attempts in total, with exponential backoff starting at initialBackoff. See WithRetryConfig.
+func WithRetry(maxAttempts int, initialBackoff time.Duration) Option {
+	return WithRetryConfig(RetryConfig{MaxAttempts: maxAttempts, InitialBackoff: initialBackoff})
+}
+
+// WithRetryConfig retries requests that failed with a 429, 500, 502, 503 or 504 response.
+// The Retry-After header of the response takes precedence over the backoff, unless it exceeds
+// RetryConfig.MaxBackoff, in which case the response is returned without retrying. Retries stop
+// when the request context is done.
+func WithRetryConfig(cfg RetryConfig) Option {
+	return func(c *client) error {
+		if err := cfg.validate(); err != nil {
+			return err
+		}
+		c.retry = &cfg
+		return nil
+	}
+}
+
+// WithTransport sets the transport used to send the HTTP requests, e.g. a mock in unit tests.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *client) error {
+		if rt == nil {
+			return errors.New("transport must not be nil")
+		}
+		c.transport = rt
+		return nil
+	}
+}
+
+// WithHTTPLogger writes the HTTP traffic of the client to w, e.g. a log file.
+// By default only the method, URL and response status of each request are written;
+// use WithHTTPLogLevel to include headers and bodies. Authorization header values are masked.
+func WithHTTPLogger(w io.Writer) Option {
+	return func(c *client) error {
+		c.httpLogWriter = w
+		return nil
+	}
+}
+
+// WithDebug writes a dump of every HTTP request and response of the client, including the bodies,
+// to w. The Authorization header value is redacted. It is meant for debugging integrations.
+func WithDebug(w io.Writer) Option {
+	return func(c *client) error {
+		c.debugWriter = w
+		return nil
+	}
+}
+
+// WithErrorBodyLogging writes the raw body of every error response to w before it is parsed,
+// so that it is preserved even if it is not valid JSON. Values that look like API keys are masked.
+func WithErrorBodyLogging(w io.Writer) Option {
+	return func(c *client) error {
+		c.errorBodyLog = nil
+		if w != nil {
+			c.errorBodyLog = &errorBodyLogger{w: w}
+		}
+		return nil
+	}
+}
+
+// WithAuditLog writes an entry for every email sent successfully by the client to w,
+// as a JSON line or a CSV record depending on the format. Failed sends are not logged.
+func WithAuditLog(w io.Writer, format AuditLogFormat) Option {
+	return func(c *client) error {
+		if w == nil {
+			return errors.New("audit log writer must not be nil")
+		}
+		if format != AuditLogJSON && format != AuditLogCSV {
+			return fmt.Errorf("unknown audit log format %d", format)
+		}
+		c.auditLog = &auditLogger{format: format, w: w}
+		return nil
+	}
+}
+
+// WithHTTPLogLevel sets the verbosity of the log enabled by WithHTTPLogger.
+func WithHTTPLogLevel(level HTTPLogLevel) Option {
+	return func(c *client) error {
+		if level < LogLevelBasic || level > LogLevelFull {
+			return fmt.Errorf("unknown HTTP log level %d", level)
+		}
+		c.httpLogLevel = level
+		return nil
+	}
+}
+
+// WithOperationTimeout limits every Do call, including waiting for a free request slot and
+// reading the response, to d. It applies on top of any deadline of the request context,
+// so the earlier of the two wins. Zero, the default, means no limit.
+func WithOperationTimeout(d time.Duration) Option {
+	return func(c *client) error {
+		if d < 0 {
+			return errors.New("operation timeout must not be negative")
+		}
+		c.operationTimeout = d
+		return nil
+	}
+}
@@ -1,13 +1,16 @@
 package mailtrap
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
 
 type PermissionsServiceContract interface {
 	ListResources(accountID int) ([]*Resource, *Response, error)
+	ListResourcesWithContext(ctx context.Context, accountID int) ([]*Resource, *Response, error)
 	Manage(accountID, accountAccessID int, permissionReq *[]PermissionRequest) (*Response, error)
+	ManageWithContext(ctx context.Context, accountID, accountAccessID int, permissionReq *[]PermissionRequest) (*Response, error)
 }
 
 type PermissionsService struct {
@@ -57,14 +60,19 @@ type permissionRequest struct {
 //
 // See https://api-docs.mailtrap.io/docs/mailtrap-api-docs/595e78d9c870b-get-resources
 func (s *PermissionsService) ListResources(accountID int) ([]*Resource, *Response, error) {
+	return s.ListResourcesWithContext(context.Background(), accountID)
+}
+
+// ListResourcesWithContext is like ListResources but uses the given context for the request.
+func (s *PermissionsService) ListResourcesWithContext(ctx context.Context, accountID int) ([]*Resource, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/permissions/resources", accountID)
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var resource []*Resource
-	res, err := s.client.Do(req, &resource)
+	res, err := s.client.Do(ctx, req, &resource)
 	if err != nil {
 		return nil, res, err
 	}
@@ -81,12 +89,21 @@ func (s *PermissionsService) ListResources(accountID int) ([]*Resource, *Respons
 func (s *PermissionsService) Manage(
 	accountID, accountAccessID int,
 	permissionReq *[]PermissionRequest,
+) (*Response, error) {
+	return s.ManageWithContext(context.Background(), accountID, accountAccessID, permissionReq)
+}
+
+// ManageWithContext is like Manage but uses the given context for the request.
+func (s *PermissionsService) ManageWithContext(
+	ctx context.Context,
+	accountID, accountAccessID int,
+	permissionReq *[]PermissionRequest,
 ) (*Response, error) {
 	u := fmt.Sprintf("/accounts/%d/account_accesses/%d/permissions/bulk", accountID, accountAccessID)
-	req, err := s.client.NewRequest(http.MethodPut, u, &permissionRequest{Permissions: permissionReq})
+	req, err := s.client.NewRequest(ctx, http.MethodPut, u, &permissionRequest{Permissions: permissionReq})
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Do(req, nil)
+	return s.client.Do(ctx, req, nil)
 }
@@ -1,21 +1,46 @@
 package mailtrap
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 )
 
 // ProjectsServiceContract defines the methods available to projects.
 type ProjectsServiceContract interface {
 	List(accountID int) ([]*Project, *Response, error)
+	ListWithContext(ctx context.Context, accountID int) ([]*Project, *Response, error)
 	Get(accountID, projectID int) (*Project, *Response, error)
+	GetWithContext(ctx context.Context, accountID, projectID int) (*Project, *Response, error)
 	Create(accountID int, name string) (*Project, *Response, error)
+	CreateWithContext(ctx context.Context, accountID int, name string) (*Project, *Response, error)
 	Update(accountID, projectID int, name string) (*Project, *Response, error)
+	UpdateWithContext(ctx context.Context, accountID, projectID int, name string) (*Project, *Response, error)
 	Delete(accountID, projectID int) (*Response, error)
+	DeleteWithContext(ctx context.Context, accountID, projectID int) (*Response, error)
+	GetByName(accountID int, name string) (*Project, *Response, error)
+	GetByNameWithContext(ctx context.Context, accountID int, name string) (*Project, *Response, error)
+	GetOrCreate(accountID int, name string) (*Project, *Response, error)
+	GetOrCreateWithContext(ctx context.Context, accountID int, name string) (*Project, *Response, error)
+	GetProjectForInbox(accountID, inboxID int) (*Project, *Response, error)
+	GetProjectForInboxWithContext(ctx context.Context, accountID, inboxID int) (*Project, *Response, error)
+	Duplicate(accountID, sourceProjectID int, newName string) (*Project, *Response, error)
+	DuplicateWithContext(ctx context.Context, accountID, sourceProjectID int, newName string) (*Project, *Response, error)
+	ClearCache()
 }
 
 type ProjectsService struct {
 	client *client
+
+	// Projects found by GetProjectForInbox, keyed by inboxProjectKey.
+	inboxProjects sync.Map
+}
+
+type inboxProjectKey struct {
+	accountID, inboxID int
 }
 
 var _ ProjectsServiceContract = &ProjectsService{}
@@ -30,6 +55,46 @@ type Project struct {
 	} `json:"share_links"`
 	Inboxes     []Inbox     `json:"inboxes"`
 	Permissions Permissions `json:"permissions"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// ShareLinksMap returns the share links keyed by access level ("admin", "viewer").
+// Links that are not set are left out.
+func (p *Project) ShareLinksMap() map[string]string {
+	links := make(map[string]string, 2)
+	if p.ShareLinks.Admin != "" {
+		links["admin"] = p.ShareLinks.Admin
+	}
+	if p.ShareLinks.Viewer != "" {
+		links["viewer"] = p.ShareLinks.Viewer
+	}
+
+	return links
+}
+
+// InboxByID returns the embedded project inbox with the given ID.
+// It does not call the API, so it only finds inboxes the project was fetched with.
+func (p *Project) InboxByID(id int) (*Inbox, bool) {
+	for i := range p.Inboxes {
+		if p.Inboxes[i].ID == id {
+			return &p.Inboxes[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// InboxByName returns the first embedded project inbox with the given name.
+// It does not call the API, so it only finds inboxes the project was fetched with.
+func (p *Project) InboxByName(name string) (*Inbox, bool) {
+	for i := range p.Inboxes {
+		if p.Inboxes[i].Name == name {
+			return &p.Inboxes[i], true
+		}
+	}
+
+	return nil, false
 }
 
 // ProjectRequest represents the request to create / update project.
@@ -43,14 +108,19 @@ type projectRequest struct {
 //
 // See: https://api-docs.mailtrap.io/docs/mailtrap-api-docs/c088109b11d07-get-a-list-of-projects
 func (s *ProjectsService) List(accountID int) ([]*Project, *Response, error) {
+	return s.ListWithContext(context.Background(), accountID)
+}
+
+// ListWithContext is like List but uses the given context for the request.
+func (s *ProjectsService) ListWithContext(ctx context.Context, accountID int) ([]*Project, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/projects", accountID)
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var project []*Project
-	res, err := s.client.Do(req, &project)
+	res, err := s.client.Do(ctx, req, &project)
 	if err != nil {
 		return nil, res, err
 	}
@@ -62,14 +132,19 @@ func (s *ProjectsService) List(accountID int) ([]*Project, *Response, error) {
 //
 // See: https://api-docs.mailtrap.io/docs/mailtrap-api-docs/3c60381e63410-get-project-by-id
 func (s *ProjectsService) Get(accountID, projectID int) (*Project, *Response, error) {
+	return s.GetWithContext(context.Background(), accountID, projectID)
+}
+
+// GetWithContext is like Get but uses the given context for the request.
+func (s *ProjectsService) GetWithContext(ctx context.Context, accountID, projectID int) (*Project, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/projects/%d", accountID, projectID)
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var project *Project
-	res, err := s.client.Do(req, &project)
+	res, err := s.client.Do(ctx, req, &project)
 	if err != nil {
 		return nil, res, err
 	}
@@ -81,19 +156,29 @@ func (s *ProjectsService) Get(accountID, projectID int) (*Project, *Response, er
 //
 // See: https://api-docs.mailtrap.io/docs/mailtrap-api-docs/e624770632299-delete-project
 func (s *ProjectsService) Delete(accountID, projectID int) (*Response, error) {
+	return s.DeleteWithContext(context.Background(), accountID, projectID)
+}
+
+// DeleteWithContext is like Delete but uses the given context for the request.
+func (s *ProjectsService) DeleteWithContext(ctx context.Context, accountID, projectID int) (*Response, error) {
 	u := fmt.Sprintf("/accounts/%d/projects/%d", accountID, projectID)
-	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, u, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Do(req, nil)
+	return s.client.Do(ctx, req, nil)
 }
 
 // Update updates project name.
 //
 // See: https://api-docs.mailtrap.io/docs/mailtrap-api-docs/73bdfaac8c86c-update-project
 func (s *ProjectsService) Update(accountID, projectID int, name string) (*Project, *Response, error) {
+	return s.UpdateWithContext(context.Background(), accountID, projectID, name)
+}
+
+// UpdateWithContext is like Update but uses the given context for the request.
+func (s *ProjectsService) UpdateWithContext(ctx context.Context, accountID, projectID int, name string) (*Project, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/projects/%d", accountID, projectID)
 	payload := &projectRequest{
 		Project: struct {
@@ -101,13 +186,13 @@ func (s *ProjectsService) Update(accountID, projectID int, name string) (*Projec
 		}{Name: name},
 	}
 
-	req, err := s.client.NewRequest(http.MethodPatch, u, payload)
+	req, err := s.client.NewRequest(ctx, http.MethodPatch, u, payload)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var project *Project
-	res, err := s.client.Do(req, &project)
+	res, err := s.client.Do(ctx, req, &project)
 	if err != nil {
 		return nil, res, err
 	}
@@ -119,6 +204,11 @@ func (s *ProjectsService) Update(accountID, projectID int, name string) (*Projec
 //
 // See: https://api-docs.mailtrap.io/docs/mailtrap-api-docs/ee252e413d78a-create-project
 func (s *ProjectsService) Create(accountID int, name string) (*Project, *Response, error) {
+	return s.CreateWithContext(context.Background(), accountID, name)
+}
+
+// CreateWithContext is like Create but uses the given context for the request.
+func (s *ProjectsService) CreateWithContext(ctx context.Context, accountID int, name string) (*Project, *Response, error) {
 	u := fmt.Sprintf("/accounts/%d/projects", accountID)
 	payload := &projectRequest{
 		Project: struct {
@@ -126,16 +216,121 @@ func (s *ProjectsService) Create(accountID int, name string) (*Project, *Respons
 		}{Name: name},
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, u, payload)
+	req, err := s.client.NewRequest(ctx, http.MethodPost, u, payload)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var project *Project
-	res, err := s.client.Do(req, &project)
+	res, err := s.client.Do(ctx, req, &project)
 	if err != nil {
 		return nil, res, err
 	}
 
 	return project, res, err
 }
+
+// GetByName returns the first project whose name matches case-insensitively,
+// or ErrNotFound if there is none.
+func (s *ProjectsService) GetByName(accountID int, name string) (*Project, *Response, error) {
+	return s.GetByNameWithContext(context.Background(), accountID, name)
+}
+
+// GetByNameWithContext is like GetByName but uses the given context for the request.
+func (s *ProjectsService) GetByNameWithContext(ctx context.Context, accountID int, name string) (*Project, *Response, error) {
+	projects, res, err := s.ListWithContext(ctx, accountID)
+	if err != nil {
+		return nil, res, err
+	}
+
+	for _, p := range projects {
+		if strings.EqualFold(p.Name, name) {
+			return p, res, nil
+		}
+	}
+
+	return nil, res, ErrNotFound
+}
+
+// GetOrCreate returns the project with the given name, creating it if it does not exist.
+func (s *ProjectsService) GetOrCreate(accountID int, name string) (*Project, *Response, error) {
+	return s.GetOrCreateWithContext(context.Background(), accountID, name)
+}
+
+// GetOrCreateWithContext is like GetOrCreate but uses the given context for the requests.
+func (s *ProjectsService) GetOrCreateWithContext(ctx context.Context, accountID int, name string) (*Project, *Response, error) {
+	project, res, err := s.GetByNameWithContext(ctx, accountID, name)
+	if err != ErrNotFound {
+		return project, res, err
+	}
+
+	return s.CreateWithContext(ctx, accountID, name)
+}
+
+// Duplicate creates a project named newName with an empty inbox for each inbox of the source project.
+// Inbox settings and messages are not copied. If creating an inbox fails, the new project is returned
+// with the inboxes created so far, so that the caller can delete it.
+func (s *ProjectsService) Duplicate(accountID, sourceProjectID int, newName string) (*Project, *Response, error) {
+	return s.DuplicateWithContext(context.Background(), accountID, sourceProjectID, newName)
+}
+
+// DuplicateWithContext is like Duplicate but uses the given context for the requests.
+func (s *ProjectsService) DuplicateWithContext(ctx context.Context, accountID, sourceProjectID int, newName string) (*Project, *Response, error) {
+	source, res, err := s.GetWithContext(ctx, accountID, sourceProjectID)
+	if err != nil {
+		return nil, res, fmt.Errorf("get source project %d: %w", sourceProjectID, err)
+	}
+	project, res, err := s.CreateWithContext(ctx, accountID, newName)
+	if err != nil {
+		return nil, res, err
+	}
+
+	inboxes := &InboxesService{client: s.client}
+	project.Inboxes = make([]Inbox, 0, len(source.Inboxes))
+	for _, src := range source.Inboxes {
+		var inbox *Inbox
+		inbox, res, err = inboxes.CreateWithContext(ctx, accountID, project.ID, src.Name)
+		if err != nil {
+			return project, res, fmt.Errorf("create inbox %q: %w", src.Name, err)
+		}
+		project.Inboxes = append(project.Inboxes, *inbox)
+	}
+
+	return project, res, nil
+}
+
+// GetProjectForInbox returns the project the inbox belongs to.
+// Results are cached for the lifetime of the client; a cached result is returned
+// with a nil Response. Use ClearCache to drop cached results.
+func (s *ProjectsService) GetProjectForInbox(accountID, inboxID int) (*Project, *Response, error) {
+	return s.GetProjectForInboxWithContext(context.Background(), accountID, inboxID)
+}
+
+// GetProjectForInboxWithContext is like GetProjectForInbox but uses the given context for the requests.
+func (s *ProjectsService) GetProjectForInboxWithContext(ctx context.Context, accountID, inboxID int) (*Project, *Response, error) {
+	key := inboxProjectKey{accountID, inboxID}
+	if project, ok := s.inboxProjects.Load(key); ok {
+		return project.(*Project), nil, nil
+	}
+
+	inbox, res, err := (&InboxesService{client: s.client}).GetWithContext(ctx, accountID, inboxID)
+	if err != nil {
+		return nil, res, err
+	}
+	project, res, err := s.GetWithContext(ctx, accountID, inbox.ProjectID)
+	if err != nil {
+		return nil, res, err
+	}
+
+	s.inboxProjects.Store(key, project)
+
+	return project, res, nil
+}
+
+// ClearCache drops the results cached by GetProjectForInbox.
+func (s *ProjectsService) ClearCache() {
+	s.inboxProjects.Range(func(key, _ interface{}) bool {
+		s.inboxProjects.Delete(key)
+		return true
+	})
+}
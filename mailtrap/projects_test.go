@@ -2,10 +2,12 @@ package mailtrap
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestProjectsService_Marshal(t *testing.T) {
@@ -237,6 +239,229 @@ func TestProjectsService_Delete(t *testing.T) {
 	})
 }
 
+func TestProjectsService_GetByName(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/projects", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":1,"name":"Staging"},{"id":2,"name":"CI Pipeline"},{"id":3,"name":"ci pipeline"}]`)
+	})
+
+	tests := map[string]int{
+		"Staging":     1,
+		"CI Pipeline": 2,
+		"ci PIPELINE": 2,
+	}
+	for name, wantID := range tests {
+		project, _, err := client.Projects.GetByName(1, name)
+		if err != nil {
+			t.Errorf("Projects.GetByName(%q) returned error: %v", name, err)
+			continue
+		}
+		if project.ID != wantID {
+			t.Errorf("Projects.GetByName(%q) returned project %d, expected %d", name, project.ID, wantID)
+		}
+	}
+
+	project, _, err := client.Projects.GetByName(1, "Production")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Projects.GetByName returned error %v, expected %v", err, ErrNotFound)
+	}
+	if project != nil {
+		t.Errorf("Projects.GetByName returned %+v, expected nil", project)
+	}
+
+	testNewRequestAndDoFail(t, "Projects.GetByName", &client.client, func() (*Response, error) {
+		project, resp, err := client.Projects.GetByName(1, "Staging")
+		if project != nil {
+			t.Errorf("Projects.GetByName client.BaseURL.Host=%v project=%#v, want nil", client.baseURL.Host, project)
+		}
+		return resp, err
+	})
+}
+
+func TestProjectsService_GetOrCreate(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	var created int
+	mux.HandleFunc("/accounts/1/projects", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `[{"id":1,"name":"Staging"}]`)
+		case http.MethodPost:
+			created++
+			fmt.Fprint(w, `{"id":2,"name":"Production"}`)
+		}
+	})
+
+	project, _, err := client.Projects.GetOrCreate(1, "staging")
+	if err != nil {
+		t.Errorf("Projects.GetOrCreate returned error: %v", err)
+	}
+	if expected := (&Project{ID: 1, Name: "Staging"}); !reflect.DeepEqual(project, expected) {
+		t.Errorf("Projects.GetOrCreate returned %+v, expected %+v", project, expected)
+	}
+	if created != 0 {
+		t.Errorf("Projects.GetOrCreate created %d projects for an existing name, expected 0", created)
+	}
+
+	project, _, err = client.Projects.GetOrCreate(1, "Production")
+	if err != nil {
+		t.Errorf("Projects.GetOrCreate returned error: %v", err)
+	}
+	if expected := (&Project{ID: 2, Name: "Production"}); !reflect.DeepEqual(project, expected) {
+		t.Errorf("Projects.GetOrCreate returned %+v, expected %+v", project, expected)
+	}
+	if created != 1 {
+		t.Errorf("Projects.GetOrCreate created %d projects, expected 1", created)
+	}
+
+	testNewRequestAndDoFail(t, "Projects.GetOrCreate", &client.client, func() (*Response, error) {
+		project, resp, err := client.Projects.GetOrCreate(1, "Staging")
+		if project != nil {
+			t.Errorf("Projects.GetOrCreate client.BaseURL.Host=%v project=%#v, want nil", client.baseURL.Host, project)
+		}
+		return resp, err
+	})
+}
+
+func TestProjectsService_Duplicate(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/projects/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":2,"name":"Staging","inboxes":[{"id":10,"name":"Signup"},{"id":11,"name":"Billing"}]}`)
+	})
+	mux.HandleFunc("/accounts/1/projects", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"id":3,"name":"Staging copy"}`)
+	})
+	var names []string
+	mux.HandleFunc("/accounts/1/projects/3/inboxes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var req createInboxRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request body: %v", err)
+		}
+		names = append(names, req.Inbox.Name)
+		fmt.Fprintf(w, `{"id":%d,"name":%q,"project_id":3}`, 19+len(names), req.Inbox.Name)
+	})
+
+	project, _, err := client.Projects.Duplicate(1, 2, "Staging copy")
+	if err != nil {
+		t.Errorf("Projects.Duplicate returned error: %v", err)
+	}
+
+	expected := &Project{ID: 3, Name: "Staging copy", Inboxes: []Inbox{
+		{ID: 20, Name: "Signup", ProjectID: 3},
+		{ID: 21, Name: "Billing", ProjectID: 3},
+	}}
+	if !reflect.DeepEqual(project, expected) {
+		t.Errorf("Projects.Duplicate returned %+v, expected %+v", project, expected)
+	}
+	if want := []string{"Signup", "Billing"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Projects.Duplicate created inboxes %v, expected %v", names, want)
+	}
+
+	_, _, err = client.Projects.Duplicate(1, 4, "Missing copy")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Projects.Duplicate returned error %v, expected %v", err, ErrNotFound)
+	}
+}
+
+func TestProjectsService_GetProjectForInbox(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	var calls int
+	mux.HandleFunc("/accounts/1/inboxes/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		calls++
+		fmt.Fprint(w, `{"id":2,"name":"inbox","project_id":3}`)
+	})
+	mux.HandleFunc("/accounts/1/projects/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		calls++
+		fmt.Fprint(w, `{"id":3,"name":"Staging"}`)
+	})
+
+	expected := &Project{ID: 3, Name: "Staging"}
+	for i := 0; i < 2; i++ {
+		project, _, err := client.Projects.GetProjectForInbox(1, 2)
+		if err != nil {
+			t.Fatalf("Projects.GetProjectForInbox returned error: %v", err)
+		}
+		if !reflect.DeepEqual(project, expected) {
+			t.Errorf("Projects.GetProjectForInbox returned %+v, expected %+v", project, expected)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Projects.GetProjectForInbox made %d API calls, expected 2", calls)
+	}
+
+	client.Projects.ClearCache()
+	if _, _, err := client.Projects.GetProjectForInbox(1, 2); err != nil {
+		t.Fatalf("Projects.GetProjectForInbox returned error: %v", err)
+	}
+	if calls != 4 {
+		t.Errorf("Projects.GetProjectForInbox after ClearCache made %d API calls, expected 4", calls)
+	}
+
+	_, _, err := client.Projects.GetProjectForInbox(1, 5)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Projects.GetProjectForInbox returned error %v, expected %v", err, ErrNotFound)
+	}
+}
+
+func TestProject_Unmarshal(t *testing.T) {
+	var withoutInboxes Project
+	data := `{"id":1,"name":"Staging","share_links":{"admin":"https://localhost/a"},"created_at":"2023-02-14T19:29:59Z"}`
+	if err := json.Unmarshal([]byte(data), &withoutInboxes); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if withoutInboxes.Inboxes != nil {
+		t.Errorf("Project.Inboxes = %+v, expected nil", withoutInboxes.Inboxes)
+	}
+	if want := time.Date(2023, 2, 14, 19, 29, 59, 0, time.UTC); !withoutInboxes.CreatedAt.Equal(want) {
+		t.Errorf("Project.CreatedAt = %v, expected %v", withoutInboxes.CreatedAt, want)
+	}
+	if want := map[string]string{"admin": "https://localhost/a"}; !reflect.DeepEqual(withoutInboxes.ShareLinksMap(), want) {
+		t.Errorf("Project.ShareLinksMap() = %v, expected %v", withoutInboxes.ShareLinksMap(), want)
+	}
+	if inbox, ok := withoutInboxes.InboxByID(2); ok {
+		t.Errorf("Project.InboxByID(2) = %+v, expected not found", inbox)
+	}
+
+	var withInboxes Project
+	data = `{"id":1,"name":"Staging","inboxes":[{"id":2,"name":"QA"},{"id":3,"name":"Dev"}]}`
+	if err := json.Unmarshal([]byte(data), &withInboxes); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if len(withInboxes.Inboxes) != 2 {
+		t.Fatalf("Project.Inboxes has %d inboxes, expected 2", len(withInboxes.Inboxes))
+	}
+}
+
+func TestProject_InboxLookup(t *testing.T) {
+	project := &Project{Inboxes: []Inbox{{ID: 2, Name: "QA"}, {ID: 3, Name: "Dev"}}}
+
+	if inbox, ok := project.InboxByID(3); !ok || inbox.Name != "Dev" {
+		t.Errorf("Project.InboxByID(3) = %+v, %v, expected Dev inbox", inbox, ok)
+	}
+	if inbox, ok := project.InboxByID(4); ok || inbox != nil {
+		t.Errorf("Project.InboxByID(4) = %+v, %v, expected nil, false", inbox, ok)
+	}
+	if inbox, ok := project.InboxByName("QA"); !ok || inbox.ID != 2 {
+		t.Errorf("Project.InboxByName(QA) = %+v, %v, expected inbox 2", inbox, ok)
+	}
+	if inbox, ok := project.InboxByName("Prod"); ok || inbox != nil {
+		t.Errorf("Project.InboxByName(Prod) = %+v, %v, expected nil, false", inbox, ok)
+	}
+}
+
 func projectMock(ID int) *Project {
 	return &Project{
 		ID:   ID,
@@ -0,0 +1,157 @@
+package mailtrap
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultMaxBackoff is the maximum backoff between two attempts if RetryConfig.MaxBackoff is not set.
+const defaultMaxBackoff = 30 * time.Second
+
+// RetryConfig configures the retries of requests that failed with a 429, 500, 502, 503 or 504 response.
+type RetryConfig struct {
+	// Maximum number of attempts, including the first one.
+	MaxAttempts int
+
+	// Backoff before the first retry. It doubles with every retry, and the actual backoff is
+	// chosen randomly between zero and the doubled value (full jitter).
+	InitialBackoff time.Duration
+
+	// Upper limit of the backoff between two attempts. Defaults to 30 seconds.
+	// A response whose Retry-After asks for a longer wait is returned without retrying.
+	MaxBackoff time.Duration
+
+	// Maximum total time spent on a request, including all attempts and backoffs.
+	// A retry that would exceed it is not made. Zero means no limit.
+	MaxElapsedTime time.Duration
+}
+
+func (cfg RetryConfig) validate() error {
+	if cfg.MaxAttempts < 1 {
+		return errors.New("retry max attempts must be at least 1")
+	}
+	if cfg.InitialBackoff < 0 || cfg.MaxBackoff < 0 || cfg.MaxElapsedTime < 0 {
+		return errors.New("retry durations must not be negative")
+	}
+
+	return nil
+}
+
+// retryTransport is an http.RoundTripper that retries requests on transient error responses.
+type retryTransport struct {
+	next http.RoundTripper
+	cfg  RetryConfig
+}
+
+// isRetryableStatus reports whether a response with the status code is worth retrying.
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// RoundTrip executes the request with the wrapped transport, retrying it on transient error responses.
+// Requests with a body that cannot be rewound are not retried.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if err != nil || !isRetryableStatus(resp.StatusCode) || attempt >= t.cfg.MaxAttempts {
+			return resp, err
+		}
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, nil
+		}
+
+		wait, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if !ok {
+			wait = t.backoff(attempt)
+		} else if wait > t.maxBackoff() {
+			// Retrying earlier than the server asks for is pointless, and waiting longer
+			// than the configured limit is not wanted.
+			return resp, nil
+		}
+		if t.cfg.MaxElapsedTime > 0 && time.Since(start)+wait > t.cfg.MaxElapsedTime {
+			return resp, nil
+		}
+
+		// Drain the body so that the connection can be reused.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// backoff returns a random duration between zero and the exponential backoff of the attempt.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	maxBackoff := t.maxBackoff()
+	d := t.cfg.InitialBackoff
+	for i := 1; i < attempt && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// maxBackoff returns the configured upper limit of the backoff, or its default.
+func (t *retryTransport) maxBackoff() time.Duration {
+	if t.cfg.MaxBackoff == 0 {
+		return defaultMaxBackoff
+	}
+
+	return t.cfg.MaxBackoff
+}
+
+// retryAfter parses the Retry-After header value, given either in seconds or as an HTTP date.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if d := date.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,227 @@
+package mailtrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// retryServer answers with the given status codes in order and with 200 once they are used up.
+func retryServer(t *testing.T, header http.Header, codes ...int) (*httptest.Server, func() ([]time.Time, []string)) {
+	t.Helper()
+
+	var (
+		mu     sync.Mutex
+		times  []time.Time
+		bodies []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		n := len(times)
+		times = append(times, time.Now())
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+
+		if n < len(codes) {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(codes[n])
+			fmt.Fprint(w, `{"errors":["try again"]}`)
+			return
+		}
+		fmt.Fprint(w, `{"success":true,"message_ids":["1"]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() ([]time.Time, []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		return times, bodies
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	const initialBackoff = 20 * time.Millisecond
+	server, requests := retryServer(t, nil, http.StatusTooManyRequests, http.StatusTooManyRequests)
+
+	client, err := NewSendingClient("api-token", WithBaseURL(server.URL), WithRetry(5, initialBackoff))
+	if err != nil {
+		t.Fatalf("NewSendingClient returned error: %v", err)
+	}
+
+	resp, _, err := client.Send(context.Background(), emailRequestMock())
+	if err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if !resp.Success {
+		t.Errorf("Send returned %+v, want success", resp)
+	}
+
+	times, bodies := requests()
+	if len(times) != 3 {
+		t.Fatalf("server received %d requests, want 3 (2 retries)", len(times))
+	}
+	for i := 1; i < len(bodies); i++ {
+		if bodies[i] != bodies[0] {
+			t.Errorf("retry %d sent body %q, want %q", i, bodies[i], bodies[0])
+		}
+	}
+	for i := 1; i < len(times); i++ {
+		// Full jitter picks a backoff between zero and initialBackoff*2^(i-1); allow some slack for scheduling.
+		limit := initialBackoff<<(i-1) + 100*time.Millisecond
+		if gap := times[i].Sub(times[i-1]); gap > limit {
+			t.Errorf("backoff before retry %d = %v, want at most %v", i, gap, limit)
+		}
+	}
+}
+
+func TestWithRetry_retryAfter(t *testing.T) {
+	server, requests := retryServer(t, http.Header{"Retry-After": []string{"1"}}, http.StatusServiceUnavailable)
+
+	client, err := NewTestingClient("api-token", WithBaseURL(server.URL), WithRetry(3, time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewTestingClient returned error: %v", err)
+	}
+	req, _ := client.NewRequest(context.Background(), http.MethodGet, "/", nil)
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	times, _ := requests()
+	if len(times) != 2 {
+		t.Fatalf("server received %d requests, want 2", len(times))
+	}
+	if gap := times[1].Sub(times[0]); gap < time.Second {
+		t.Errorf("backoff = %v, want at least the Retry-After of 1s", gap)
+	}
+}
+
+func TestWithRetry_retryAfterExceedsMaxBackoff(t *testing.T) {
+	server, requests := retryServer(t, http.Header{"Retry-After": []string{"3600"}}, http.StatusTooManyRequests)
+
+	client, err := NewTestingClient("api-token", WithBaseURL(server.URL),
+		WithRetryConfig(RetryConfig{MaxAttempts: 3, MaxBackoff: time.Second}))
+	if err != nil {
+		t.Fatalf("NewTestingClient returned error: %v", err)
+	}
+
+	start := time.Now()
+	_, _, err = client.Accounts.List()
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Accounts.List returned error %v, want the 429 response", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Accounts.List took %v, want it to return without waiting", elapsed)
+	}
+	if times, _ := requests(); len(times) != 1 {
+		t.Errorf("server received %d requests, want 1", len(times))
+	}
+}
+
+func TestWithRetry_exhausted(t *testing.T) {
+	server, requests := retryServer(t, nil,
+		http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+
+	client, err := NewTestingClient("api-token", WithBaseURL(server.URL), WithRetry(3, time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewTestingClient returned error: %v", err)
+	}
+	_, _, err = client.Accounts.List()
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusBadGateway {
+		t.Errorf("Accounts.List returned error %v, want a 502 error response", err)
+	}
+	if times, _ := requests(); len(times) != 3 {
+		t.Errorf("server received %d requests, want 3", len(times))
+	}
+}
+
+func TestWithRetry_notRetryable(t *testing.T) {
+	server, requests := retryServer(t, nil, http.StatusBadRequest)
+
+	client, err := NewTestingClient("api-token", WithBaseURL(server.URL), WithRetry(3, time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewTestingClient returned error: %v", err)
+	}
+	if _, _, err := client.Accounts.List(); err == nil {
+		t.Error("Accounts.List returned no error for a 400 response")
+	}
+	if times, _ := requests(); len(times) != 1 {
+		t.Errorf("server received %d requests, want 1", len(times))
+	}
+}
+
+func TestWithRetry_canceled(t *testing.T) {
+	server, _ := retryServer(t, nil, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+	client, err := NewSendingClient("api-token", WithBaseURL(server.URL),
+		WithRetryConfig(RetryConfig{MaxAttempts: 3, InitialBackoff: 10 * time.Second, MaxBackoff: 10 * time.Second}))
+	if err != nil {
+		t.Fatalf("NewSendingClient returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _, err = client.Send(ctx, emailRequestMock())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Send returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Send took %v, want it to stop retrying when the context is done", elapsed)
+	}
+}
+
+func TestWithRetry_maxElapsedTime(t *testing.T) {
+	server, requests := retryServer(t, http.Header{"Retry-After": []string{"10"}}, http.StatusTooManyRequests)
+
+	client, err := NewTestingClient("api-token", WithBaseURL(server.URL),
+		WithRetryConfig(RetryConfig{MaxAttempts: 3, MaxElapsedTime: time.Second}))
+	if err != nil {
+		t.Fatalf("NewTestingClient returned error: %v", err)
+	}
+	if _, _, err := client.Accounts.List(); err == nil {
+		t.Error("Accounts.List returned no error, want the 429 response")
+	}
+	if times, _ := requests(); len(times) != 1 {
+		t.Errorf("server received %d requests, want 1", len(times))
+	}
+
+	for _, opt := range []Option{WithRetry(0, time.Second), WithRetry(1, -time.Second)} {
+		if _, err := NewTestingClient("api-token", opt); err == nil {
+			t.Error("NewTestingClient with invalid retry config, err = nil, want error")
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{now.Add(2 * time.Second).Format(http.TimeFormat), 2 * time.Second, true},
+		{now.Add(-time.Hour).Format(http.TimeFormat), 0, true},
+	}
+	for _, tt := range tests {
+		got, ok := retryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("retryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
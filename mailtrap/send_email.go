@@ -1,32 +1,54 @@
 package mailtrap
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
+	"io"
+	"mime"
 	"net/http"
+	"net/mail"
 	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // SendEmailRequest represents the request to send email.
 type SendEmailRequest struct {
 	From EmailAddress   `json:"from"`
-	To   []EmailAddress `json:"to"`
-	Cc   []EmailAddress `json:"cc"`
-	Bcc  []EmailAddress `json:"bcc"`
+	To   []EmailAddress `json:"to,omitempty"`
+	Cc   []EmailAddress `json:"cc,omitempty"`
+	Bcc  []EmailAddress `json:"bcc,omitempty"`
+
+	// Addresses that replies to the email are sent to instead of the 'from' address.
+	ReplyTo []EmailAddress `json:"reply_to,omitempty"`
+
+	// Address that bounces are sent to (Return-Path). It is omitted when its email is empty.
+	ReturnPath EmailAddress `json:"return_path,omitempty"`
 
 	// An array of objects where you can specify any attachments you want to include.
-	Attachments []EmailAttachment `json:"attachments"`
+	Attachments []EmailAttachment `json:"attachments,omitempty"`
 
 	// An object containing key/value pairs of header names and the value to substitute for them.
 	// The key/value pairs must be strings.
 	// You must ensure these are properly encoded if they contain unicode characters.
 	// These headers cannot be one of the reserved headers.
-	Headers map[string]string `json:"headers"`
+	Headers map[HeaderName]string `json:"headers,omitempty"`
 
 	// Values that are specific to the entire send that will be carried along with the email and its activity data.
 	// Total size of custom variables in JSON form must not exceed 1000 bytes.
-	CustomVars map[string]string `json:"custom_variables"`
+	CustomVars map[string]string `json:"custom_variables,omitempty"`
 
 	// The global or 'message level' subject of your email.
 	// This may be overridden by subject lines set in personalizations.
@@ -40,14 +62,170 @@ type SendEmailRequest struct {
 	// Required in the absence of text.
 	HTML     string `json:"html"`
 	Category string `json:"category"`
+
+	// UUID of a template stored in Mailtrap to render the subject and body from.
+	// When it is set, the subject, text and html can be left empty.
+	TemplateUUID string `json:"template_uuid,omitempty"`
+
+	// Values substituted for the variables of the template.
+	TemplateVariables map[string]interface{} `json:"template_variables,omitempty"`
+
+	// Importance of the email from 1 (highest) to 5 (lowest), as in the X-Priority header of RFC 4021.
+	// Zero leaves the priority unset.
+	Priority int `json:"priority,omitempty"`
+
+	// Time to deliver the email at, for scheduled sending. Nil sends it immediately.
+	// It is sent in RFC 3339 format and must be in the future.
+	SendAt *time.Time `json:"send_at,omitempty"`
+
+	// Per-recipient overrides of message-level settings. They do not add recipients: the email is
+	// still delivered to the To, Cc and Bcc addresses, and a personalization applies to the recipient
+	// with the same email. Recipients without a personalization get the message-level settings.
+	Personalizations []Personalization `json:"personalizations,omitempty"`
+}
+
+// Personalization overrides message-level settings for a single recipient.
+type Personalization struct {
+	// The recipient the overrides apply to. Required.
+	Email EmailAddress `json:"email"`
+
+	// Subject of the email for the recipient, overriding the message-level subject.
+	Subject string `json:"subject,omitempty"`
+
+	// Dynamic template data of the recipient.
+	Data map[string]interface{} `json:"data,omitempty"`
+
+	// Custom variables of the recipient. They are merged with the message-level custom variables,
+	// overriding those with the same key. The merged variables must not exceed 1000 bytes in JSON form.
+	CustomVars map[string]string `json:"custom_variables,omitempty"`
+}
+
+// maxCustomVarsSize is the maximum size of the custom variables in JSON form.
+const maxCustomVarsSize = 1000
+
+// customVarsSize returns the size of the custom variables in JSON form. They are encoded
+// the same way NewRequest encodes the request body, without the trailing newline.
+func customVarsSize(vars map[string]string) int {
+	buf := new(bytes.Buffer)
+	_ = json.NewEncoder(buf).Encode(vars)
+	return len(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
 }
 
+// MarshalJSON omits the return path when its email is empty, so that the API never receives
+// an empty object for it, and formats SendAt in RFC 3339. Empty slice and map fields are omitted
+// by their omitempty tags. When TemplateUUID is set the subject, text and HTML are omitted, since
+// the template provides them and the API rejects a request that sets both.
+func (r SendEmailRequest) MarshalJSON() ([]byte, error) {
+	type request SendEmailRequest
+	type payload struct {
+		request
+		ReturnPath *EmailAddress `json:"return_path,omitempty"`
+		SendAt     string        `json:"send_at,omitempty"`
+	}
+	p := payload{request: request(r)}
+	if r.ReturnPath.Email != "" {
+		p.ReturnPath = &r.ReturnPath
+	}
+	if r.SendAt != nil {
+		p.SendAt = r.SendAt.Format(time.RFC3339)
+	}
+	if r.TemplateUUID == "" {
+		return json.Marshal(p)
+	}
+	return json.Marshal(struct {
+		payload
+		Subject *string `json:"subject,omitempty"`
+		Text    *string `json:"text,omitempty"`
+		HTML    *string `json:"html,omitempty"`
+	}{payload: p})
+}
+
+// HeaderName is the name of a custom email header.
+type HeaderName string
+
+// Common custom header names.
+const (
+	HeaderXMessageSource = HeaderName("X-Message-Source")
+	HeaderXMailer        = HeaderName("X-Mailer")
+	HeaderXPriority      = HeaderName("X-Priority")
+	HeaderXEntityRefID   = HeaderName("X-Entity-Ref-ID")
+)
+
+// Threading header names, used to link a reply to the original message.
+const (
+	HeaderInReplyTo  = HeaderName("In-Reply-To")
+	HeaderReferences = HeaderName("References")
+)
+
+// MaxAttachments is the maximum number of attachments per email.
+const MaxAttachments = 40
+
+// MaxAttachmentFileSize is the maximum size of a file read by NewAttachmentFromFile.
+const MaxAttachmentFileSize = 25 << 20
+
+// Limits on custom headers, enforced by validation to avoid the API rejecting oversized requests.
+const (
+	MaxHeaderKeyLength   = 64
+	MaxHeaderValueLength = 1000
+	MaxHeaderCount       = 25
+)
+
+// safeHeaderName matches the custom header names allowed by WithSafeHeaders.
+var safeHeaderName = regexp.MustCompile(`^X-[A-Za-z0-9-]+$`)
+
 // EmailAddress represents an email address.
 type EmailAddress struct {
 	Email string `json:"email"`
 	Name  string `json:"name"`
 }
 
+// Validate checks that the email is present and is a valid RFC 5322 address without a display name.
+// The returned error is a ValidationError for the "email" field.
+func (a EmailAddress) Validate() error {
+	if a.Email == "" {
+		return ValidationError{Field: "email", Message: "'email' is required"}
+	}
+	if !isValidEmail(a.Email) {
+		return ValidationError{Field: "email", Message: "'email' is invalid"}
+	}
+
+	return nil
+}
+
+// isValidEmail reports whether s is a bare RFC 5322 address such as "john@example.com".
+func isValidEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+// Redact returns a copy of the address that is safe to log. The email is redacted
+// by RedactEmailAddress and the name is reduced to its initials, e.g. "John Doe" becomes "JD".
+func (a EmailAddress) Redact() EmailAddress {
+	redacted := EmailAddress{Email: RedactEmailAddress(a.Email)}
+
+	var initials strings.Builder
+	for _, word := range strings.Fields(a.Name) {
+		first, _ := utf8.DecodeRuneInString(word)
+		initials.WriteRune(unicode.ToUpper(first))
+	}
+	redacted.Name = initials.String()
+
+	return redacted
+}
+
+// RedactEmailAddresses returns a copy of the addresses with each address redacted by EmailAddress.Redact.
+func RedactEmailAddresses(addrs []EmailAddress) []EmailAddress {
+	if addrs == nil {
+		return nil
+	}
+	redacted := make([]EmailAddress, len(addrs))
+	for i, a := range addrs {
+		redacted[i] = a.Redact()
+	}
+
+	return redacted
+}
+
 // EmailAttachment represents an email attachment.
 type EmailAttachment struct {
 	// The Base64 encoded content of the attachment.
@@ -73,12 +251,423 @@ type EmailAttachment struct {
 	// This is used when the disposition is set to “inline” and the attachment is an image,
 	// allowing the file to be displayed within the body of your email.
 	ContentID string `json:"content_id"`
+
+	// The encoding of the content. Only "base64" is supported, which the API assumes when omitted.
+	ContentTransferEncoding string `json:"content_transfer_encoding,omitempty"`
+}
+
+// NewAttachmentFromReader reads the content of an attachment from r and returns it base64-encoded
+// in an attachment with the "attachment" disposition.
+func NewAttachmentFromReader(r io.Reader, filename, mimeType string) (EmailAttachment, error) {
+	if r == nil {
+		return EmailAttachment{}, fmt.Errorf("read attachment %q: reader is nil", filename)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return EmailAttachment{}, fmt.Errorf("read attachment %q: %w", filename, err)
+	}
+
+	return EmailAttachment{
+		Content:     base64.StdEncoding.EncodeToString(data),
+		AttachType:  mimeType,
+		Filename:    filename,
+		Disposition: "attachment",
+	}, nil
+}
+
+// NewInlineAttachmentFromReader is like NewAttachmentFromReader but returns an inline attachment
+// with a generated content ID, see EnsureContentID.
+func NewInlineAttachmentFromReader(r io.Reader, filename, mimeType string) (EmailAttachment, error) {
+	a, err := NewAttachmentFromReader(r, filename, mimeType)
+	if err != nil {
+		return EmailAttachment{}, err
+	}
+	a.Disposition = "inline"
+	a.EnsureContentID()
+
+	return a, nil
+}
+
+// NewAttachmentFromFile reads the file at path and returns it base64-encoded in an attachment
+// named after the file. The MIME type is detected from the file extension, falling back to
+// "application/octet-stream". Files larger than MaxAttachmentFileSize are rejected.
+func NewAttachmentFromFile(path string) (EmailAttachment, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return EmailAttachment{}, fmt.Errorf("read attachment: %w", err)
+	}
+	defer f.Close()
+
+	filename := filepath.Base(path)
+	tooLarge := fmt.Errorf("read attachment %q: file is larger than %d bytes", filename, MaxAttachmentFileSize)
+	if info, err := f.Stat(); err == nil && info.Size() > MaxAttachmentFileSize {
+		return EmailAttachment{}, tooLarge
+	}
+	// The limit is checked again while reading in case the file grows after the stat.
+	data, err := io.ReadAll(io.LimitReader(f, MaxAttachmentFileSize+1))
+	if err != nil {
+		return EmailAttachment{}, fmt.Errorf("read attachment %q: %w", filename, err)
+	}
+	if len(data) > MaxAttachmentFileSize {
+		return EmailAttachment{}, tooLarge
+	}
+
+	mimeType := mime.TypeByExtension(filepath.Ext(path))
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+
+	return NewAttachmentFromReader(bytes.NewReader(data), filename, mimeType)
+}
+
+// EnsureContentID returns the attachment's content ID, generating and assigning
+// a new one in the "<uuid@mailtrap-go>" format if it is not set.
+// The generated ID can be referenced in HTML as "cid:" followed by the ID without angle brackets.
+func (a *EmailAttachment) EnsureContentID() string {
+	if a.ContentID == "" {
+		a.ContentID = "<" + newUUID() + "@mailtrap-go>"
+	}
+
+	return a.ContentID
+}
+
+// contentIDFormat matches a content ID in the message-ID format, e.g. "<logo@example.com>".
+var contentIDFormat = regexp.MustCompile(`^<[^<>@\s]+@[^<>@\s]+>$`)
+
+// DecodeContent returns the raw bytes of the base64-encoded attachment content.
+func (a *EmailAttachment) DecodeContent() ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(a.Content)
+	if err != nil {
+		return nil, fmt.Errorf("decode content of attachment %q: %w", a.Filename, err)
+	}
+
+	return data, nil
+}
+
+// EnsureInlineContentIDs assigns a content ID to every inline attachment that lacks one.
+func (r *SendEmailRequest) EnsureInlineContentIDs() *SendEmailRequest {
+	for i := range r.Attachments {
+		if r.Attachments[i].Disposition == "inline" {
+			r.Attachments[i].EnsureContentID()
+		}
+	}
+
+	return r
+}
+
+// newUUID returns a random (version 4) UUID string.
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
 }
 
 // SendEmailResponse contains response from email sending API.
 type SendEmailResponse struct {
 	Success    bool     `json:"success"`
 	MessageIDs []string `json:"message_ids"`
+
+	// Errors lists the recipients the email could not be sent to when delivery partially failed.
+	Errors []SendError `json:"errors,omitempty"`
+}
+
+// SendError describes a failed delivery to a single recipient.
+type SendError struct {
+	Email     string `json:"email"`
+	ErrorCode string `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+// HasErrors reports whether sending failed for any recipient. It is safe to call on a nil response.
+func (r *SendEmailResponse) HasErrors() bool {
+	return r != nil && len(r.Errors) > 0
+}
+
+// SuccessfulCount returns the number of messages sent successfully. It is safe to call on a nil response.
+func (r *SendEmailResponse) SuccessfulCount() int {
+	if r == nil {
+		return 0
+	}
+
+	return len(r.MessageIDs)
+}
+
+// FirstMessageID returns the first message ID and whether it exists.
+func (r *SendEmailResponse) FirstMessageID() (string, bool) {
+	if r == nil || len(r.MessageIDs) == 0 {
+		return "", false
+	}
+
+	return r.MessageIDs[0], true
+}
+
+// AllMessageIDs returns all message IDs. It is safe to call on a nil response.
+func (r *SendEmailResponse) AllMessageIDs() []string {
+	if r == nil {
+		return nil
+	}
+
+	return r.MessageIDs
+}
+
+// WithCategoryIfEmpty sets the category only if it is not already set.
+func (r *SendEmailRequest) WithCategoryIfEmpty(category string) *SendEmailRequest {
+	if r.Category == "" {
+		r.Category = category
+	}
+
+	return r
+}
+
+// WithSubjectIfEmpty sets the subject only if it is not already set.
+func (r *SendEmailRequest) WithSubjectIfEmpty(subject string) *SendEmailRequest {
+	if r.Subject == "" {
+		r.Subject = subject
+	}
+
+	return r
+}
+
+// WithTextIfEmpty sets the text body only if it is not already set.
+func (r *SendEmailRequest) WithTextIfEmpty(text string) *SendEmailRequest {
+	if r.Text == "" {
+		r.Text = text
+	}
+
+	return r
+}
+
+// WithHTMLIfEmpty sets the HTML body only if it is not already set.
+func (r *SendEmailRequest) WithHTMLIfEmpty(html string) *SendEmailRequest {
+	if r.HTML == "" {
+		r.HTML = html
+	}
+
+	return r
+}
+
+// WithContent sets the HTML or the text body depending on the media type, "text/html" or "text/plain".
+// Media type parameters such as the charset are ignored.
+func (r *SendEmailRequest) WithContent(content, mediaType string) (*SendEmailRequest, error) {
+	mt, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return r, fmt.Errorf("parse media type %q: %w", mediaType, err)
+	}
+	switch mt {
+	case "text/html":
+		r.HTML = content
+	case "text/plain":
+		r.Text = content
+	default:
+		return r, fmt.Errorf("unsupported media type %q", mediaType)
+	}
+
+	return r, nil
+}
+
+// NormalizeEmails lowercases the email addresses of the sender, all recipients and the reply-to addresses in place.
+// Display names are left unchanged.
+func (r *SendEmailRequest) NormalizeEmails() *SendEmailRequest {
+	r.From.Email = strings.ToLower(r.From.Email)
+	for _, addrs := range [][]EmailAddress{r.To, r.Cc, r.Bcc, r.ReplyTo} {
+		for i := range addrs {
+			addrs[i].Email = strings.ToLower(addrs[i].Email)
+		}
+	}
+
+	return r
+}
+
+// NewReplyAllRequest returns a request replying to all participants of the original message:
+// To is set to the original sender, Cc to the original recipients except the sender,
+// and the subject is prefixed with "Re:". When the original Message-ID is known,
+// the In-Reply-To and References headers are set to thread the reply.
+// From and Text are left blank for the caller to fill in. It returns nil for a nil message.
+func NewReplyAllRequest(original *Message) *SendEmailRequest {
+	if original == nil {
+		return nil
+	}
+
+	r := &SendEmailRequest{
+		To:      []EmailAddress{{Email: original.FromEmail, Name: original.FromName}},
+		Subject: "Re: " + stripReplyPrefix(original.Subject),
+	}
+
+	seen := map[string]bool{strings.ToLower(original.FromEmail): true}
+	names := strings.Split(original.ToName, ",")
+	for i, addr := range strings.Split(original.ToEmail, ",") {
+		addr = strings.TrimSpace(addr)
+		key := strings.ToLower(addr)
+		if addr == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		var name string
+		if i < len(names) {
+			name = strings.TrimSpace(names[i])
+		}
+		r.Cc = append(r.Cc, EmailAddress{Email: addr, Name: name})
+	}
+
+	if original.MessageID != "" {
+		r.Headers = map[HeaderName]string{
+			HeaderInReplyTo:  original.MessageID,
+			HeaderReferences: original.MessageID,
+		}
+	}
+
+	return r
+}
+
+// stripReplyPrefix removes any leading "Re:" prefixes from the subject.
+func stripReplyPrefix(subject string) string {
+	subject = strings.TrimSpace(subject)
+	for len(subject) >= 3 && strings.EqualFold(subject[:3], "re:") {
+		subject = strings.TrimSpace(subject[3:])
+	}
+
+	return subject
+}
+
+// EstimateSize returns the approximate size of the email in bytes: the sum of the text and HTML bodies,
+// the subject, the custom headers and the base64-encoded attachments (decoded size × 4/3).
+func (r *SendEmailRequest) EstimateSize() int64 {
+	size := int64(len(r.HTML) + len(r.Text) + len(r.Subject))
+	for k, v := range r.Headers {
+		size += int64(len(k) + len(v))
+	}
+	for _, a := range r.Attachments {
+		// Content is already base64-encoded, so its length includes the encoding overhead.
+		size += int64(len(a.Content))
+	}
+
+	return size
+}
+
+// TotalAttachmentSize returns the total decoded size of the attachments in bytes,
+// computed from the length of the base64-encoded content.
+func (r *SendEmailRequest) TotalAttachmentSize() int64 {
+	var size int64
+	for _, a := range r.Attachments {
+		content := strings.TrimRight(a.Content, "=")
+		size += int64(len(content)) * 3 / 4
+	}
+
+	return size
+}
+
+// ToLogSafeString returns a JSON summary of the request suitable for audit logs.
+// Email addresses are redacted; the body, headers and custom variables are left out.
+func (r *SendEmailRequest) ToLogSafeString() string {
+	summary := struct {
+		From        string   `json:"from"`
+		To          []string `json:"to,omitempty"`
+		Cc          []string `json:"cc,omitempty"`
+		Bcc         []string `json:"bcc,omitempty"`
+		Subject     string   `json:"subject"`
+		Category    string   `json:"category,omitempty"`
+		Attachments []string `json:"attachments,omitempty"`
+	}{
+		From:     RedactEmailAddress(r.From.Email),
+		To:       redactAddresses(r.To),
+		Cc:       redactAddresses(r.Cc),
+		Bcc:      redactAddresses(r.Bcc),
+		Subject:  r.Subject,
+		Category: r.Category,
+	}
+	for _, a := range r.Attachments {
+		summary.Attachments = append(summary.Attachments, a.Filename)
+	}
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+// ParseSendEmailRequestFromMap converts a loosely-typed map, e.g. an email job decoded from a message queue,
+// into a SendEmailRequest. The map must contain the "from" and "to" keys and either the "template_uuid" key
+// or the "subject" key and at least one of the "text" and "html" keys.
+func ParseSendEmailRequestFromMap(m map[string]interface{}) (*SendEmailRequest, error) {
+	required := []string{"from", "to"}
+	_, template := m["template_uuid"]
+	if !template {
+		required = append(required, "subject")
+	}
+	for _, key := range required {
+		if _, ok := m[key]; !ok {
+			return nil, fmt.Errorf("parse send email request: missing required key %q", key)
+		}
+	}
+	if !template {
+		if _, ok := m["text"]; !ok {
+			if _, ok := m["html"]; !ok {
+				return nil, errors.New(`parse send email request: missing required key "text" or "html"`)
+			}
+		}
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("parse send email request: %w", err)
+	}
+	var r SendEmailRequest
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parse send email request: %w", err)
+	}
+
+	return &r, nil
+}
+
+// SendEmailRequestToMap converts the request into a map with the same keys as its JSON form.
+// It is the inverse of ParseSendEmailRequestFromMap.
+func SendEmailRequestToMap(r *SendEmailRequest) (map[string]interface{}, error) {
+	if r == nil {
+		return nil, errors.New("request `SendEmailRequest` is mandatory")
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// RedactEmailAddress masks the local part of the address except for its first character,
+// e.g. "john@example.com" becomes "j***@example.com".
+func RedactEmailAddress(addr string) string {
+	if addr == "" {
+		return ""
+	}
+	at := strings.LastIndex(addr, "@")
+	if at <= 0 {
+		return "***"
+	}
+
+	_, size := utf8.DecodeRuneInString(addr)
+	return addr[:size] + "***" + addr[at:]
+}
+
+func redactAddresses(addrs []EmailAddress) []string {
+	var redacted []string
+	for _, a := range addrs {
+		redacted = append(redacted, RedactEmailAddress(a.Email))
+	}
+
+	return redacted
 }
 
 // ProductionSendingClient manages communication with the Mailtrap sending API.
@@ -86,34 +675,118 @@ type ProductionSendingClient struct {
 	client
 }
 
+// SendHTMLOnly sends an HTML email with a text fallback generated by StripHTMLForText.
+func (sc *ProductionSendingClient) SendHTMLOnly(
+	ctx context.Context,
+	from EmailAddress,
+	to []EmailAddress,
+	subject, html string,
+) (*SendEmailResponse, *Response, error) {
+	if strings.TrimSpace(html) == "" {
+		return nil, nil, ValidationErrors{{Field: "html", Message: "'html' is required"}}
+	}
+
+	return sc.Send(ctx, &SendEmailRequest{
+		From:    from,
+		To:      to,
+		Subject: subject,
+		HTML:    html,
+		Text:    StripHTMLForText(html),
+	})
+}
+
 // Send email
 //
 // See: https://api-docs.mailtrap.io/docs/mailtrap-api-docs/67f1d70aeb62c-send-email
-func (sc *ProductionSendingClient) Send(request *SendEmailRequest) (*SendEmailResponse, *Response, error) {
+func (sc *ProductionSendingClient) Send(ctx context.Context, request *SendEmailRequest) (*SendEmailResponse, *Response, error) {
 	if request == nil {
 		return nil, nil, errors.New("request `SendEmailRequest` is mandatory")
 	}
 
-	if err := request.validate(); err != nil {
+	sc.prepare(request)
+	if err := sc.validate(request); err != nil {
 		return nil, nil, err
 	}
 
-	req, err := sc.NewRequest(http.MethodPost, "/send", request)
+	req, err := sc.NewRequest(ctx, http.MethodPost, "/send", request)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	response := new(SendEmailResponse)
-	res, err := sc.Do(req, response)
+	res, err := sc.Do(ctx, req, response)
 	if err != nil {
 		return nil, res, err
 	}
+	sc.auditLog.log(request, response)
 
 	return response, res, err
 }
 
-func (sc *ProductionSendingClient) setBaseURL(u url.URL) {
-	sc.baseURL = u
+// DeliveryStatus represents the delivery state of a sent message.
+type DeliveryStatus struct {
+	MessageID string `json:"message_id"`
+
+	// Status can be queued, delivered, bounced, etc.
+	Status       string     `json:"status"`
+	DeliveredAt  *time.Time `json:"delivered_at"`
+	BouncedAt    *time.Time `json:"bounced_at"`
+	BounceReason string     `json:"bounce_reason"`
+}
+
+// GetDeliveryStatus returns the delivery status of a message by the ID returned from Send.
+func (sc *ProductionSendingClient) GetDeliveryStatus(
+	ctx context.Context,
+	messageID string,
+) (*DeliveryStatus, *Response, error) {
+	if messageID == "" {
+		return nil, nil, errors.New("'messageID' is required")
+	}
+
+	req, err := sc.NewRequest(ctx, http.MethodGet, "/messages/"+url.PathEscape(messageID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var status *DeliveryStatus
+	res, err := sc.Do(ctx, req, &status)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return status, res, nil
+}
+
+// Verifier is implemented by clients that can check the API key and connectivity.
+type Verifier interface {
+	GetAccountInfo(ctx context.Context) (*AccountInfo, *Response, error)
+}
+
+var _ Verifier = &ProductionSendingClient{}
+
+// AccountInfo represents the account the API key belongs to.
+type AccountInfo struct {
+	AccountID       int    `json:"account_id"`
+	Plan            string `json:"plan"`
+	EmailsSentToday int    `json:"emails_sent_today"`
+	DailyLimit      int    `json:"daily_limit"`
+}
+
+// GetAccountInfo returns information about the account the API key belongs to.
+// It can be used to verify the API key before sending; an invalid key returns an error matching ErrUnauthorized.
+func (sc *ProductionSendingClient) GetAccountInfo(ctx context.Context) (*AccountInfo, *Response, error) {
+	req, err := sc.NewRequest(ctx, http.MethodGet, "/account", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var info *AccountInfo
+	res, err := sc.Do(ctx, req, &info)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return info, res, nil
 }
 
 // SandboxSendingClient manages communication with the Mailtrap sandbox API.
@@ -125,75 +798,280 @@ type SandboxSendingClient struct {
 // Send email
 //
 // See: https://api-docs.mailtrap.io/docs/mailtrap-api-docs/bcf61cdc1547e-send-email-including-templates
-func (sc *SandboxSendingClient) Send(request *SendEmailRequest) (*SendEmailResponse, *Response, error) {
+func (sc *SandboxSendingClient) Send(ctx context.Context, request *SendEmailRequest) (*SendEmailResponse, *Response, error) {
 	if request == nil {
 		return nil, nil, errors.New("request `SendEmailRequest` is mandatory")
 	}
 
-	if err := request.validate(); err != nil {
+	sc.prepare(request)
+	if err := sc.validate(request); err != nil {
 		return nil, nil, err
 	}
 
-	req, err := sc.NewRequest(http.MethodPost, fmt.Sprintf("/send/%v", sc.inboxID), request)
+	req, err := sc.NewRequest(ctx, http.MethodPost, fmt.Sprintf("/send/%v", sc.inboxID), request)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	response := new(SendEmailResponse)
-	res, err := sc.Do(req, response)
+	res, err := sc.Do(ctx, req, response)
 	if err != nil {
 		return nil, res, err
 	}
+	sc.auditLog.log(request, response)
 
 	return response, res, err
 }
 
-func (sc *SandboxSendingClient) setBaseURL(u url.URL) {
-	sc.baseURL = u
+// prepare applies the client-level request transformations before validation.
+func (c *client) prepare(r *SendEmailRequest) {
+	if c.autoHTMLFromText && r.HTML == "" && r.Text != "" {
+		r.HTML = textToHTML(r.Text)
+	}
+	if c.autoTextFallback && r.Text == "" && r.HTML != "" {
+		r.Text = StripHTMLForText(r.HTML)
+	}
+}
+
+// validate validates the request along with the client-level validation rules.
+// The failures of both are returned together as ValidationErrors.
+func (c *client) validate(r *SendEmailRequest) error {
+	var errs ValidationErrors
+	if err := r.Validate(); err != nil && !errors.As(err, &errs) {
+		return err
+	}
+
+	if c.strictValidation {
+		for _, field := range r.sameDomainRecipients() {
+			errs.add(field+".email", fmt.Sprintf("'%s' address has the same domain as 'from' address", field))
+		}
+	}
+
+	if c.safeHeaders {
+		var unsafe []string
+		for name := range r.Headers {
+			if !safeHeaderName.MatchString(string(name)) {
+				unsafe = append(unsafe, string(name))
+			}
+		}
+		sort.Strings(unsafe)
+		for _, name := range unsafe {
+			errs.add("headers."+name, fmt.Sprintf("'%s' header must be a custom X- header", name))
+		}
+	}
+
+	if len(c.verifiedDomains) > 0 && !c.isVerifiedDomain(emailDomain(r.From.Email)) {
+		errs = append(errs, ValidationError{
+			Field:   "from.email",
+			Message: fmt.Sprintf("%v: %s", ErrUnverifiedSenderDomain, emailDomain(r.From.Email)),
+			err:     ErrUnverifiedSenderDomain,
+		})
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// isVerifiedDomain reports whether the domain is one of the verified domains or their subdomain.
+func (c *client) isVerifiedDomain(domain string) bool {
+	for _, d := range c.verifiedDomains {
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasValidDomain performs a coarse syntactic check of the address domain:
+// it must contain at least one dot and end with a top-level domain of at least two characters.
+// No DNS lookup is performed.
+func hasValidDomain(addr string) bool {
+	at := strings.LastIndex(addr, "@")
+	if at <= 0 {
+		return false
+	}
+	domain := addr[at+1:]
+	dot := strings.LastIndex(domain, ".")
+	if dot <= 0 {
+		return false
+	}
+
+	return len(domain)-dot-1 >= 2
+}
+
+// textToHTML wraps the escaped text in a minimal HTML document.
+func textToHTML(text string) string {
+	return "<html><body><pre>" + html.EscapeString(text) + "</pre></body></html>"
+}
+
+var (
+	htmlSkippedElements = regexp.MustCompile(`(?is)<(script|style|head)\b.*?</(script|style|head)\s*>`)
+	htmlLineBreaks      = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|tr|h[1-6]|blockquote|pre|table)\s*>`)
+	htmlTags            = regexp.MustCompile(`(?s)<[^>]*>`)
+	horizontalSpace     = regexp.MustCompile(`[ \t\f\v\x{00a0}]+`)
+	blankLines          = regexp.MustCompile(`\n{3,}`)
+)
+
+// StripHTMLForText converts an HTML body to a plain text alternative: scripts and styles
+// are dropped, block-level elements and <br> end lines, the remaining tags are removed
+// and entities are unescaped.
+func StripHTMLForText(body string) string {
+	text := htmlSkippedElements.ReplaceAllString(body, "")
+	text = htmlLineBreaks.ReplaceAllString(text, "\n")
+	text = htmlTags.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(horizontalSpace.ReplaceAllString(line, " "))
+	}
+	text = blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+
+	return strings.TrimSpace(text)
 }
 
-// Send email request validation
-func (r *SendEmailRequest) validate() error {
+// Validate checks the request and returns all the problems found at once as ValidationErrors,
+// or nil if the request is valid. It is called by Send before the request is made.
+func (r *SendEmailRequest) Validate() error {
+	var errs ValidationErrors
+
 	if r.From.Email == "" {
-		return errors.New("'from' address is required")
+		errs.add("from.email", "'from' address is required")
+	} else if !hasValidDomain(r.From.Email) {
+		errs.add("from.email", "'from' address has an invalid domain")
+	} else if r.From.Validate() != nil {
+		errs.add("from.email", "'from' address is invalid")
+	}
+	if r.ReturnPath.Email != "" && !isValidEmail(r.ReturnPath.Email) {
+		errs.add("return_path.email", "'return_path' address is invalid")
 	}
 
 	if len(r.To) == 0 {
-		return errors.New("'to' address is required")
+		errs.add("to", "'to' address is required")
 	}
-	for _, v := range r.To {
-		if v.Email == "" {
-			return errors.New("'email' is required in 'to' address")
+	errs.addAddresses("to", r.To)
+	errs.addAddresses("cc", r.Cc)
+	errs.addAddresses("bcc", r.Bcc)
+	errs.addAddresses("reply_to", r.ReplyTo)
+	for i, p := range r.Personalizations {
+		var ve ValidationError
+		if errors.As(p.Email.Validate(), &ve) {
+			errs.add(fmt.Sprintf("personalizations[%d].email.%s", i, ve.Field), ve.Message+" in personalization")
 		}
 	}
 
-	if len(r.Attachments) > 0 {
-		var errMsg []string
-		for _, v := range r.Attachments {
-			if v.Content == "" {
-				errMsg = append(errMsg, "'content' is required in attachment")
+	if len(r.Attachments) > MaxAttachments {
+		errs.add("attachments", fmt.Sprintf("email cannot have more than %d attachments", MaxAttachments))
+	}
+	contentIDs := make(map[string]bool)
+	for i, v := range r.Attachments {
+		field := fmt.Sprintf("attachments[%d]", i)
+		if v.Content == "" {
+			errs.add(field+".content", "'content' is required in attachment")
+		}
+		if v.Filename == "" {
+			errs.add(field+".filename", "'filename' is required in attachment")
+		}
+		if v.ContentTransferEncoding != "" && v.ContentTransferEncoding != "base64" {
+			errs.add(field+".content_transfer_encoding", "'content_transfer_encoding' must be base64 in attachment")
+		}
+		if v.ContentID != "" {
+			if !contentIDFormat.MatchString(v.ContentID) {
+				errs.add(field+".content_id", "'content_id' must be in the <id@domain> format in attachment: "+v.ContentID)
 			}
-			if v.Filename == "" {
-				errMsg = append(errMsg, "'filename' is required in attachment")
+			if contentIDs[v.ContentID] {
+				errs.add(field+".content_id", "duplicate 'content_id' in attachments: "+v.ContentID)
 			}
-		}
-		if len(errMsg) > 0 {
-			return errors.New(strings.Join(errMsg, "; "))
+			contentIDs[v.ContentID] = true
 		}
 	}
 
-	if r.Subject == "" {
-		return errors.New("'subject' is required")
+	if r.TemplateUUID != "" {
+		if strings.TrimSpace(r.TemplateUUID) == "" {
+			errs.add("template_uuid", "'template_uuid' must not be blank")
+		}
+	} else {
+		if r.Subject == "" {
+			errs.add("subject", "'subject' is required")
+		}
+		if r.Text == "" && r.HTML == "" {
+			errs.add("text", "one of 'text' or 'html' is required")
+		}
 	}
 
-	if r.Text == "" && r.HTML == "" {
-		return errors.New("one of 'text' or 'html' is required")
+	if r.Priority < 0 || r.Priority > 5 {
+		errs.add("priority", "'priority' must be between 1 and 5")
+	}
+	if r.SendAt != nil && !r.SendAt.After(time.Now()) {
+		errs.add("send_at", "'send_at' must be in the future")
 	}
 
 	const categoryMaxLength int = 255
 	if len(r.Category) > categoryMaxLength {
-		return fmt.Errorf("'category' is greater than %d chars", categoryMaxLength)
+		errs.add("category", fmt.Sprintf("'category' is greater than %d chars", categoryMaxLength))
+	}
+
+	if customVarsSize(r.CustomVars) > maxCustomVarsSize {
+		errs.add("custom_variables", fmt.Sprintf("'custom_variables' exceeds %d bytes", maxCustomVarsSize))
+	}
+	for i, p := range r.Personalizations {
+		if len(p.CustomVars) == 0 {
+			continue
+		}
+		merged := make(map[string]string, len(r.CustomVars)+len(p.CustomVars))
+		for k, v := range r.CustomVars {
+			merged[k] = v
+		}
+		for k, v := range p.CustomVars {
+			merged[k] = v
+		}
+		if customVarsSize(merged) > maxCustomVarsSize {
+			errs.add(fmt.Sprintf("personalizations[%d].custom_variables", i),
+				fmt.Sprintf("'custom_variables' for recipient %s are greater than %d bytes", p.Email.Email, maxCustomVarsSize))
+		}
+	}
+
+	r.validateHeaders(&errs)
+
+	if len(errs) > 0 {
+		return errs
 	}
 
 	return nil
 }
+
+// addAddresses adds an error for every invalid address of the field, e.g. "to[1].email".
+func (e *ValidationErrors) addAddresses(field string, addrs []EmailAddress) {
+	for i, a := range addrs {
+		var ve ValidationError
+		if errors.As(a.Validate(), &ve) {
+			e.add(fmt.Sprintf("%s[%d].%s", field, i, ve.Field), fmt.Sprintf("%s in '%s' address", ve.Message, field))
+		}
+	}
+}
+
+// validateHeaders checks the custom headers against the header count and size limits.
+func (r *SendEmailRequest) validateHeaders(errs *ValidationErrors) {
+	if len(r.Headers) > MaxHeaderCount {
+		errs.add("headers", fmt.Sprintf("'headers' cannot have more than %d headers", MaxHeaderCount))
+	}
+
+	names := make([]string, 0, len(r.Headers))
+	for name := range r.Headers {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if len(name) > MaxHeaderKeyLength {
+			errs.add("headers."+name, fmt.Sprintf("header '%s' name is greater than %d chars", name, MaxHeaderKeyLength))
+		}
+		if len(r.Headers[HeaderName(name)]) > MaxHeaderValueLength {
+			errs.add("headers."+name, fmt.Sprintf("header '%s' value is greater than %d chars", name, MaxHeaderValueLength))
+		}
+	}
+}
@@ -1,16 +1,40 @@
 package mailtrap
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
+	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"testing/iotest"
+	"time"
 )
 
 func TestSendEmailService_Marshal(t *testing.T) {
 	testJSONMarshal(t, &SendEmailRequest{}, "{}")
 
+	empty, err := json.Marshal(&SendEmailRequest{})
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	for _, key := range []string{"to", "cc", "bcc", "reply_to", "return_path", "attachments", "headers", "custom_variables", "template_variables", "personalizations"} {
+		if strings.Contains(string(empty), `"`+key+`"`) {
+			t.Errorf("json.Marshal(SendEmailRequest{}) returned %s, want %q omitted", empty, key)
+		}
+	}
+
 	req := emailRequestMock()
 	want := `{
 	  "from": {
@@ -59,6 +83,215 @@ func TestSendEmailService_Marshal(t *testing.T) {
 	}`
 
 	testJSONMarshal(t, req, want)
+
+	req.ReplyTo = []EmailAddress{{Email: "support@example.com", Name: "Support"}}
+	want = strings.Replace(want, `"attachments": [`, `"reply_to": [
+	    {
+	      "email": "support@example.com",
+	      "name": "Support"
+	    }
+	  ],
+	  "attachments": [`, 1)
+	testJSONMarshal(t, req, want)
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	var got SendEmailRequest
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got.ReplyTo, req.ReplyTo) {
+		t.Errorf("round trip ReplyTo = %+v, want %+v", got.ReplyTo, req.ReplyTo)
+	}
+
+	req.ReturnPath = EmailAddress{Email: "bounces@example.com"}
+	want = strings.Replace(want, `"reply_to": [`, `"return_path": {
+	    "email": "bounces@example.com",
+	    "name": ""
+	  },
+	  "reply_to": [`, 1)
+	testJSONMarshal(t, req, want)
+
+	req.ReturnPath = EmailAddress{Name: "Bounces"}
+	if data, _ := json.Marshal(req); strings.Contains(string(data), "return_path") {
+		t.Errorf("json.Marshal with an empty return path email returned %s, want it omitted", data)
+	}
+}
+
+func TestSendEmailRequest_MarshalJSON_sendAt(t *testing.T) {
+	sendAt := time.Date(2030, 1, 2, 15, 4, 5, 123, time.FixedZone("CET", 3600))
+	req := emailRequestMock()
+	req.SendAt = &sendAt
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	if want := `"send_at":"2030-01-02T15:04:05+01:00"`; !strings.Contains(string(data), want) {
+		t.Errorf("json.Marshal returned %s, want it to contain %s", data, want)
+	}
+
+	var got SendEmailRequest
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if got.SendAt == nil || !got.SendAt.Equal(sendAt.Truncate(time.Second)) {
+		t.Errorf("round trip SendAt = %v, want %v", got.SendAt, sendAt.Truncate(time.Second))
+	}
+}
+
+func TestSendEmailRequest_MarshalJSON_personalizations(t *testing.T) {
+	req := &SendEmailRequest{
+		From:    EmailAddress{Email: "ches@example.com", Name: "Ches"},
+		To:      []EmailAddress{{Email: "john@example.com"}, {Email: "mary@example.com"}},
+		Subject: "Your order",
+		Text:    "Thank you for your order.",
+		Personalizations: []Personalization{
+			{
+				Email:   EmailAddress{Email: "john@example.com", Name: "John"},
+				Subject: "Your order, John",
+				Data:    map[string]interface{}{"order_id": 1, "items": []interface{}{"book"}},
+			},
+			{
+				Email:      EmailAddress{Email: "mary@example.com"},
+				CustomVars: map[string]string{"user_id": "2"},
+			},
+		},
+	}
+	want := `{
+	  "from": {"email": "ches@example.com", "name": "Ches"},
+	  "to": [{"email": "john@example.com", "name": ""}, {"email": "mary@example.com", "name": ""}],
+	  "subject": "Your order",
+	  "text": "Thank you for your order.",
+	  "html": "",
+	  "category": "",
+	  "personalizations": [
+	    {
+	      "email": {"email": "john@example.com", "name": "John"},
+	      "subject": "Your order, John",
+	      "data": {"order_id": 1, "items": ["book"]}
+	    },
+	    {
+	      "email": {"email": "mary@example.com", "name": ""},
+	      "custom_variables": {"user_id": "2"}
+	    }
+	  ]
+	}`
+	testJSONMarshal(t, req, want)
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	wantJSON := `"personalizations":[` +
+		`{"email":{"email":"john@example.com","name":"John"},"subject":"Your order, John","data":{"items":["book"],"order_id":1}},` +
+		`{"email":{"email":"mary@example.com","name":""},"custom_variables":{"user_id":"2"}}]`
+	if !strings.Contains(string(data), wantJSON) {
+		t.Errorf("json.Marshal returned %s, want it to contain %s", data, wantJSON)
+	}
+}
+
+func TestSendEmailRequest_priority(t *testing.T) {
+	tests := []struct {
+		priority int
+		wantJSON string
+		wantErr  bool
+	}{
+		{priority: 0},
+		{priority: 1, wantJSON: `"priority":1`},
+		{priority: 3, wantJSON: `"priority":3`},
+		{priority: 5, wantJSON: `"priority":5`},
+		{priority: 6, wantJSON: `"priority":6`, wantErr: true},
+		{priority: -1, wantJSON: `"priority":-1`, wantErr: true},
+	}
+	for _, tt := range tests {
+		req := emailRequestMock()
+		req.Priority = tt.priority
+
+		data, err := json.Marshal(req)
+		if err != nil {
+			t.Fatalf("json.Marshal returned error: %v", err)
+		}
+		if tt.wantJSON == "" && strings.Contains(string(data), `"priority"`) {
+			t.Errorf("json.Marshal with priority %d returned %s, want it omitted", tt.priority, data)
+		}
+		if tt.wantJSON != "" && !strings.Contains(string(data), tt.wantJSON) {
+			t.Errorf("json.Marshal with priority %d returned %s, want it to contain %s", tt.priority, data, tt.wantJSON)
+		}
+
+		err = req.Validate()
+		if tt.wantErr {
+			want := ValidationErrors{{Field: "priority", Message: "'priority' must be between 1 and 5"}}
+			var errs ValidationErrors
+			if !errors.As(err, &errs) || !reflect.DeepEqual(errs, want) {
+				t.Errorf("Validate() with priority %d returned error %v, want %v", tt.priority, err, want)
+			}
+		} else if err != nil {
+			t.Errorf("Validate() with priority %d returned error: %v", tt.priority, err)
+		}
+	}
+}
+
+func TestSendEmailRequest_MarshalJSON_emptyCollections(t *testing.T) {
+	base := `"from":{"email":"ches@example.com","name":""},"subject":"Subj.","text":"Test","html":"","category":""`
+
+	tests := []struct {
+		name string
+		req  SendEmailRequest
+		want string
+	}{
+		{
+			name: "nil slices and maps",
+			req:  SendEmailRequest{},
+			want: `{` + base + `}`,
+		},
+		{
+			name: "empty slices and maps",
+			req: SendEmailRequest{
+				To:          []EmailAddress{},
+				Cc:          []EmailAddress{},
+				Bcc:         []EmailAddress{},
+				Attachments: []EmailAttachment{},
+				Headers:     map[HeaderName]string{},
+				CustomVars:  map[string]string{},
+			},
+			want: `{` + base + `}`,
+		},
+		{
+			name: "non-empty slices and maps",
+			req: SendEmailRequest{
+				To:         []EmailAddress{{Email: "john@example.com"}},
+				Cc:         []EmailAddress{{Email: "info@example.com"}},
+				Headers:    map[HeaderName]string{HeaderXMailer: "mailtrap-go"},
+				CustomVars: map[string]string{"user_id": "1"},
+			},
+			want: `{"from":{"email":"ches@example.com","name":""},"to":[{"email":"john@example.com","name":""}],` +
+				`"cc":[{"email":"info@example.com","name":""}],"headers":{"X-Mailer":"mailtrap-go"},` +
+				`"custom_variables":{"user_id":"1"},"subject":"Subj.","text":"Test","html":"","category":""}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.From = EmailAddress{Email: "ches@example.com"}
+			tt.req.Subject = "Subj."
+			tt.req.Text = "Test"
+
+			got, err := json.Marshal(&tt.req)
+			if err != nil {
+				t.Fatalf("json.Marshal returned error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json.Marshal returned\n%s\nwant\n%s", got, tt.want)
+			}
+
+			got, _ = json.Marshal(tt.req)
+			if string(got) != tt.want {
+				t.Errorf("json.Marshal by value returned\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
 }
 
 func TestSendEmailService_Send(t *testing.T) {
@@ -71,7 +304,7 @@ func TestSendEmailService_Send(t *testing.T) {
 	})
 
 	email := emailRequestMock()
-	sendResp, _, err := client.Send(email)
+	sendResp, _, err := client.Send(context.Background(), email)
 	if err != nil {
 		t.Errorf("SendEmail.Send returned error: %v", err)
 	}
@@ -87,7 +320,7 @@ func TestSendEmailService_Send(t *testing.T) {
 		t.Errorf("SendEmail.Send returned %v, want %v", sendResp, emailResp)
 	}
 
-	_, _, err = client.Send(nil)
+	_, _, err = client.Send(context.Background(), nil)
 	if err == nil {
 		t.Error("SendEmail.Send bad request, err = nil, want error")
 	}
@@ -99,7 +332,7 @@ func TestSendEmailService_Send(t *testing.T) {
 	}
 
 	testNewRequestAndDoFail(t, "SendEmail.Send", &c.client, func() (*Response, error) {
-		deliveredEmailIDs, resp, err := client.Send(email)
+		deliveredEmailIDs, resp, err := client.Send(context.Background(), email)
 		if deliveredEmailIDs != nil {
 			t.Errorf("SendEmail.Send client.BaseURL.Host=%v sendEmailResp=%#v, want nil", c.baseURL.Host, deliveredEmailIDs)
 		}
@@ -107,34 +340,323 @@ func TestSendEmailService_Send(t *testing.T) {
 	})
 }
 
+// TestSendEmailService_Send_concurrent is meant to be run with the race detector.
+func TestSendEmailService_Send_concurrent(t *testing.T) {
+	client, mux, teardown := setupSendingClient()
+	defer teardown()
+
+	var calls int32
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		fmt.Fprint(w, `{"success":true,"message_ids":["1"]}`)
+	})
+
+	const goroutines = 50
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.Send(context.Background(), emailRequestMock()); err != nil {
+				t.Errorf("SendEmail.Send returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&calls); n != goroutines {
+		t.Errorf("server received %d requests, want %d", n, goroutines)
+	}
+}
+
 func TestSendEmailService_Send_notValidEmailFrom(t *testing.T) {
 	client, _, teardown := setupSendingClient()
 	defer teardown()
 
-	email := &SendEmailRequest{To: []EmailAddress{{Email: "test@example.com"}}}
-	_, _, err := client.Send(email)
+	email := &SendEmailRequest{To: []EmailAddress{{Email: "test@example.com"}}, Subject: "Subj.", Text: "Test"}
+	_, _, err := client.Send(context.Background(), email)
 	if err.Error() != "'from' address is required" {
 		t.Errorf("SendEmail.Send returned error: %v", err)
 	}
 }
 
+func TestSendEmailService_Send_notValidFromDomain(t *testing.T) {
+	tests := map[string]bool{
+		"user@example.com":     true,
+		"user@mail.example.io": true,
+		"user@gmail.con":       true,
+		"user@localhost":       false,
+		"user@":                false,
+		"user@example.c":       false,
+		"user@.com":            false,
+		"@example.com":         false,
+	}
+	for addr, valid := range tests {
+		email := &SendEmailRequest{
+			From:    EmailAddress{Email: addr},
+			To:      []EmailAddress{{Email: "email@example.com"}},
+			Subject: "Subj.",
+			Text:    "Test",
+		}
+		err := email.Validate()
+		if valid && err != nil {
+			t.Errorf("Validate() with from %q returned error: %v", addr, err)
+		}
+		if !valid && (err == nil || err.Error() != "'from' address has an invalid domain") {
+			t.Errorf("Validate() with from %q returned error: %v, want invalid domain", addr, err)
+		}
+	}
+}
+
+func TestSendEmailService_Send_verifiedDomains(t *testing.T) {
+	tests := []struct {
+		name    string
+		domains []string
+		from    string
+		wantErr bool
+	}{
+		{"exact match", []string{"example.com", "example.org"}, "ches@example.org", false},
+		{"case-insensitive match", []string{"Example.com"}, "ches@EXAMPLE.com", false},
+		{"subdomain match", []string{"example.com"}, "ches@mail.example.com", false},
+		{"non-matching domain", []string{"example.com"}, "ches@badexample.com", true},
+		{"no config", nil, "ches@anything.io", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, teardown := setupSendingClient(WithVerifiedDomains(tt.domains))
+			defer teardown()
+
+			mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"success":true,"message_ids":["1"]}`)
+			})
+
+			email := &SendEmailRequest{
+				From:    EmailAddress{Email: tt.from},
+				To:      []EmailAddress{{Email: "john@other.net"}},
+				Subject: "Subj.",
+				Text:    "Test",
+			}
+			_, _, err := client.Send(context.Background(), email)
+			if got := IsUnverifiedSenderDomainError(err); got != tt.wantErr {
+				t.Errorf("SendEmail.Send returned error %v, want unverified sender domain error: %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("SendEmail.Send returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSendEmailService_Send_clientValidationErrors(t *testing.T) {
+	client, _, teardown := setupSendingClient(WithVerifiedDomains([]string{"example.com"}), WithSafeHeaders(), WithStrictValidation())
+	defer teardown()
+
+	email := &SendEmailRequest{
+		From:    EmailAddress{Email: "ches@other.net"},
+		To:      []EmailAddress{{Email: "john@other.net"}},
+		Text:    "Test",
+		Headers: map[HeaderName]string{"Reply-To": "a@example.com"},
+	}
+	_, _, err := client.Send(context.Background(), email)
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("SendEmail.Send returned error %v, want ValidationErrors", err)
+	}
+	var fields []string
+	for _, e := range errs {
+		fields = append(fields, e.Field)
+	}
+	if want := []string{"subject", "to[0].email", "headers.Reply-To", "from.email"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("SendEmail.Send returned errors for fields %v, expected %v", fields, want)
+	}
+	if !IsUnverifiedSenderDomainError(err) {
+		t.Errorf("IsUnverifiedSenderDomainError(%v) = false, want true", err)
+	}
+}
+
+func TestSendEmailService_Send_safeHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[HeaderName]string
+		wantErr string
+	}{
+		{"custom headers", map[HeaderName]string{HeaderXMessageSource: "example.com", "X-Campaign-42": "a"}, ""},
+		{"standard headers", map[HeaderName]string{"Subject": "Hi", "Reply-To": "a@example.com"}, "'Reply-To' header must be a custom X- header; 'Subject' header must be a custom X- header"},
+		{"non X- prefix", map[HeaderName]string{"Campaign": "a"}, "'Campaign' header must be a custom X- header"},
+		{"invalid characters", map[HeaderName]string{"X-Bad_Name": "a"}, "'X-Bad_Name' header must be a custom X- header"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, teardown := setupSendingClient(WithSafeHeaders())
+			defer teardown()
+
+			mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"success":true,"message_ids":["1"]}`)
+			})
+
+			email := &SendEmailRequest{
+				From:    EmailAddress{Email: "ches@example.com"},
+				To:      []EmailAddress{{Email: "john@other.net"}},
+				Subject: "Subj.",
+				Text:    "Test",
+				Headers: tt.headers,
+			}
+			_, _, err := client.Send(context.Background(), email)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("SendEmail.Send returned error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("SendEmail.Send returned error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	lenient, mux, teardown := setupSendingClient()
+	defer teardown()
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true,"message_ids":["1"]}`)
+	})
+	email := emailRequestMock()
+	email.Headers["Subject"] = "Hi"
+	if _, _, err := lenient.Send(context.Background(), email); err != nil {
+		t.Errorf("SendEmail.Send without safe headers returned error: %v", err)
+	}
+}
+
 func TestSendEmailService_Send_notValidEmailTo(t *testing.T) {
 	client, _, teardown := setupSendingClient()
 	defer teardown()
 
-	email := &SendEmailRequest{From: EmailAddress{Email: "test@example.com"}}
-	_, _, err := client.Send(email)
+	email := &SendEmailRequest{From: EmailAddress{Email: "test@example.com"}, Subject: "Subj.", Text: "Test"}
+	_, _, err := client.Send(context.Background(), email)
 	if err.Error() != "'to' address is required" {
 		t.Errorf("SendEmail.Send returned error: %v", err)
 	}
 
-	email = &SendEmailRequest{From: EmailAddress{Email: "test@example.com"}, To: []EmailAddress{{Email: ""}}}
-	_, _, err = client.Send(email)
+	email.To = []EmailAddress{{Email: ""}}
+	_, _, err = client.Send(context.Background(), email)
 	if err.Error() != "'email' is required in 'to' address" {
 		t.Errorf("SendEmail.Send returned error: %v", err)
 	}
 }
 
+func TestSendEmailService_Send_notValidEmailReplyTo(t *testing.T) {
+	client, _, teardown := setupSendingClient()
+	defer teardown()
+
+	email := emailRequestMock()
+	email.ReplyTo = []EmailAddress{{Email: "support@example.com"}, {Name: "Support"}}
+	_, _, err := client.Send(context.Background(), email)
+	if err == nil || err.Error() != "'email' is required in 'reply_to' address" {
+		t.Errorf("SendEmail.Send returned error: %v", err)
+	}
+}
+
+func TestEmailAddress_Validate(t *testing.T) {
+	tests := map[string]string{
+		"john@example.com":     "",
+		"john.doe+tag@ex.io":   "",
+		"":                     "'email' is required",
+		"notanemail":           "'email' is invalid",
+		"@nodomain":            "'email' is invalid",
+		"john@":                "'email' is invalid",
+		"John <john@ex.com>":   "'email' is invalid",
+		" john@example.com":    "'email' is invalid",
+		"john@example.com, me": "'email' is invalid",
+	}
+	for addr, want := range tests {
+		err := EmailAddress{Email: addr}.Validate()
+		if want == "" {
+			if err != nil {
+				t.Errorf("Validate() with %q returned error: %v", addr, err)
+			}
+			continue
+		}
+		var ve ValidationError
+		if !errors.As(err, &ve) || ve.Field != "email" || ve.Message != want {
+			t.Errorf("Validate() with %q returned error %v, want %q", addr, err, want)
+		}
+	}
+}
+
+func TestSendEmailRequest_validate_addresses(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *SendEmailRequest)
+		want   ValidationErrors
+	}{
+		{
+			name:   "from",
+			modify: func(r *SendEmailRequest) { r.From.Email = "ches smith@example.com" },
+			want:   ValidationErrors{{Field: "from.email", Message: "'from' address is invalid"}},
+		},
+		{
+			name:   "to",
+			modify: func(r *SendEmailRequest) { r.To[1].Email = "notanemail" },
+			want:   ValidationErrors{{Field: "to[1].email", Message: "'email' is invalid in 'to' address"}},
+		},
+		{
+			name:   "cc",
+			modify: func(r *SendEmailRequest) { r.Cc[0].Email = "@nodomain" },
+			want:   ValidationErrors{{Field: "cc[0].email", Message: "'email' is invalid in 'cc' address"}},
+		},
+		{
+			name:   "bcc",
+			modify: func(r *SendEmailRequest) { r.Bcc[0].Email = "" },
+			want:   ValidationErrors{{Field: "bcc[0].email", Message: "'email' is required in 'bcc' address"}},
+		},
+		{
+			name:   "reply_to",
+			modify: func(r *SendEmailRequest) { r.ReplyTo = []EmailAddress{{Email: "support"}} },
+			want:   ValidationErrors{{Field: "reply_to[0].email", Message: "'email' is invalid in 'reply_to' address"}},
+		},
+		{
+			name: "several",
+			modify: func(r *SendEmailRequest) {
+				r.To[0].Email = "john@"
+				r.Cc[0].Email = "info"
+			},
+			want: ValidationErrors{
+				{Field: "to[0].email", Message: "'email' is invalid in 'to' address"},
+				{Field: "cc[0].email", Message: "'email' is invalid in 'cc' address"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email := emailRequestMock()
+			tt.modify(email)
+
+			var errs ValidationErrors
+			if err := email.Validate(); !errors.As(err, &errs) {
+				t.Fatalf("Validate() returned error %v, want ValidationErrors", err)
+			}
+			if !reflect.DeepEqual(errs, tt.want) {
+				t.Errorf("Validate() returned %+v, expected %+v", errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendEmailRequest_validate_returnPath(t *testing.T) {
+	email := emailRequestMock()
+	email.ReturnPath = EmailAddress{Email: "bounces@example.com"}
+	if err := email.Validate(); err != nil {
+		t.Errorf("Validate() returned error: %v", err)
+	}
+
+	email.ReturnPath.Email = "bounces"
+	var errs ValidationErrors
+	if err := email.Validate(); !errors.As(err, &errs) {
+		t.Fatalf("Validate() returned error %v, want ValidationErrors", err)
+	}
+	want := ValidationErrors{{Field: "return_path.email", Message: "'return_path' address is invalid"}}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("Validate() returned %+v, expected %+v", errs, want)
+	}
+}
+
 func TestSendEmailService_Send_notValidAttachmentIfExist(t *testing.T) {
 	client, _, teardown := setupSendingClient()
 	defer teardown()
@@ -143,61 +665,1309 @@ func TestSendEmailService_Send_notValidAttachmentIfExist(t *testing.T) {
 		From:        EmailAddress{Email: "test@example.com"},
 		To:          []EmailAddress{{Email: "email@example.com"}},
 		Attachments: []EmailAttachment{{}},
+		Subject:     "Subj.",
+		Text:        "Test",
 	}
 
-	_, _, err := client.Send(email)
+	_, _, err := client.Send(context.Background(), email)
 	if err.Error() != "'content' is required in attachment; 'filename' is required in attachment" {
 		t.Errorf("SendEmail.Send returned error: %v", err)
 	}
 }
 
-func TestSendEmailService_Send_missedSubject(t *testing.T) {
-	client, _, teardown := setupSendingClient()
-	defer teardown()
+func TestSendEmailService_Send_attachmentTransferEncoding(t *testing.T) {
+	tests := map[string]bool{
+		"base64":           true,
+		"":                 true,
+		"quoted-printable": false,
+	}
+	for encoding, valid := range tests {
+		email := &SendEmailRequest{
+			From:    EmailAddress{Email: "test@example.com"},
+			To:      []EmailAddress{{Email: "email@example.com"}},
+			Subject: "Subj.",
+			Text:    "Test",
+			Attachments: []EmailAttachment{
+				{Content: "SGVsbG8=", Filename: "hello.txt", ContentTransferEncoding: encoding},
+			},
+		}
+		err := email.Validate()
+		if valid && err != nil {
+			t.Errorf("Validate() with encoding %q returned error: %v", encoding, err)
+		}
+		if !valid && (err == nil || err.Error() != "'content_transfer_encoding' must be base64 in attachment") {
+			t.Errorf("Validate() with encoding %q returned error: %v", encoding, err)
+		}
+	}
+}
 
-	email := &SendEmailRequest{
-		From:    EmailAddress{Email: "test@example.com"},
-		To:      []EmailAddress{{Email: "email@example.com"}},
-		Subject: "",
+func TestSendEmailRequest_validate_contentIDs(t *testing.T) {
+	tests := []struct {
+		name       string
+		contentIDs []string
+		wantErr    string
+	}{
+		{
+			name:       "unique",
+			contentIDs: []string{"<logo@example.com>", "<banner@example.com>"},
+		},
+		{
+			name:       "duplicate",
+			contentIDs: []string{"<logo@example.com>", "<banner@example.com>", "<logo@example.com>"},
+			wantErr:    "duplicate 'content_id' in attachments: <logo@example.com>",
+		},
+		{
+			name:       "missing angle bracket",
+			contentIDs: []string{"logo@example.com>"},
+			wantErr:    "'content_id' must be in the <id@domain> format in attachment: logo@example.com>",
+		},
+		{
+			name:       "set and unset",
+			contentIDs: []string{"", "<logo@example.com>", ""},
+		},
 	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email := &SendEmailRequest{
+				From:    EmailAddress{Email: "test@example.com"},
+				To:      []EmailAddress{{Email: "email@example.com"}},
+				Subject: "Subj.",
+				Text:    "Test",
+			}
+			for i, id := range tt.contentIDs {
+				email.Attachments = append(email.Attachments, EmailAttachment{
+					Content:   "SGVsbG8=",
+					Filename:  fmt.Sprintf("image%d.png", i),
+					ContentID: id,
+				})
+			}
 
-	_, _, err := client.Send(email)
-	if err.Error() != "'subject' is required" {
-		t.Errorf("SendEmail.Send returned error: %v", err)
+			err := email.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() returned error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() returned error %v, want %q", err, tt.wantErr)
+			}
+		})
 	}
 }
 
-func TestSendEmailService_Send_textOrHTMLRequired(t *testing.T) {
-	client, _, teardown := setupSendingClient()
-	defer teardown()
+func TestSendEmailRequest_validate_maxAttachments(t *testing.T) {
+	for count, wantErr := range map[int]bool{MaxAttachments: false, MaxAttachments + 1: true} {
+		email := &SendEmailRequest{
+			From:    EmailAddress{Email: "test@example.com"},
+			To:      []EmailAddress{{Email: "email@example.com"}},
+			Subject: "Subj.",
+			Text:    "Test",
+		}
+		for i := 0; i < count; i++ {
+			email.Attachments = append(email.Attachments, EmailAttachment{Content: "SGVsbG8=", Filename: "hello.txt"})
+		}
 
-	email := &SendEmailRequest{
-		From:    EmailAddress{Email: "test@example.com"},
-		To:      []EmailAddress{{Email: "email@example.com"}},
-		Subject: "Subj.",
+		err := email.Validate()
+		if wantErr && (err == nil || err.Error() != "email cannot have more than 40 attachments") {
+			t.Errorf("Validate() with %d attachments returned error %v, want attachment limit error", count, err)
+		}
+		if !wantErr && err != nil {
+			t.Errorf("Validate() with %d attachments returned error: %v", count, err)
+		}
+	}
+}
+
+func TestSendEmailRequest_TotalAttachmentSize(t *testing.T) {
+	req := &SendEmailRequest{}
+	for _, n := range []int{0, 1, 2, 3, 100} {
+		req.Attachments = append(req.Attachments, EmailAttachment{
+			Content: base64.StdEncoding.EncodeToString(make([]byte, n)),
+		})
 	}
 
-	_, _, err := client.Send(email)
-	if err.Error() != "one of 'text' or 'html' is required" {
-		t.Errorf("SendEmail.Send returned error: %v", err)
+	if got, want := req.TotalAttachmentSize(), int64(0+1+2+3+100); got != want {
+		t.Errorf("TotalAttachmentSize() = %d, want %d", got, want)
 	}
 }
 
-func TestSendEmailService_Send_categoryTooLong(t *testing.T) {
-	client, _, teardown := setupSendingClient()
+func TestSendEmailService_Send_canceled(t *testing.T) {
+	client, mux, teardown := setupSendingClient()
+	defer teardown()
+
+	received := make(chan struct{})
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		close(received)
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+		fmt.Fprint(w, `{"success":true,"message_ids":["1"]}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-received
+		cancel()
+	}()
+
+	start := time.Now()
+	_, _, err := client.Send(ctx, emailRequestMock())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Send returned error %v, expected %v", err, context.Canceled)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Send took %v, expected it to return once the context is canceled", elapsed)
+	}
+}
+
+func TestSendEmailService_Send_customVarsTooLarge(t *testing.T) {
+	client, mux, teardown := setupSendingClient()
 	defer teardown()
 
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Send made a request for custom variables over the size limit")
+	})
+
+	email := emailRequestMock()
+	email.CustomVars = make(map[string]string)
+	for i := 0; i < 5; i++ {
+		email.CustomVars[fmt.Sprintf("var_%d", i)] = strings.Repeat("v", 200)
+	}
+
+	_, _, err := client.Send(context.Background(), email)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("SendEmail.Send returned error %v, want ValidationErrors", err)
+	}
+	want := ValidationErrors{{Field: "custom_variables", Message: "'custom_variables' exceeds 1000 bytes"}}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("SendEmail.Send returned %+v, expected %+v", errs, want)
+	}
+
+	delete(email.CustomVars, "var_0")
+	if err := email.Validate(); err != nil {
+		t.Errorf("Validate() returned error: %v", err)
+	}
+}
+
+func TestSendEmailRequest_Validate_personalizationEmail(t *testing.T) {
+	email := emailRequestMock()
+	email.Personalizations = []Personalization{
+		{Email: EmailAddress{Email: "johndoe@example.com"}, Subject: "Hi John"},
+		{Email: EmailAddress{Name: "Mike"}, Subject: "Hi Mike"},
+	}
+
+	var errs ValidationErrors
+	if err := email.Validate(); !errors.As(err, &errs) {
+		t.Fatalf("Validate() returned error %v, want ValidationErrors", err)
+	}
+	want := ValidationErrors{{Field: "personalizations[1].email.email", Message: "'email' is required in personalization"}}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("Validate() returned %+v, expected %+v", errs, want)
+	}
+
+	email.Personalizations[1].Email.Email = "mike@example.com"
+	if err := email.Validate(); err != nil {
+		t.Errorf("Validate() returned error: %v", err)
+	}
+}
+
+func TestSendEmailRequest_validate_personalizationCustomVars(t *testing.T) {
 	email := &SendEmailRequest{
-		From:     EmailAddress{Email: "test@example.com"},
-		To:       []EmailAddress{{Email: "email@example.com"}},
-		Subject:  "Subj.",
+		From:       EmailAddress{Email: "test@example.com"},
+		To:         []EmailAddress{{Email: "john@example.com"}, {Email: "mary@example.com"}},
+		Subject:    "Subj.",
+		Text:       "Test",
+		CustomVars: map[string]string{"campaign": strings.Repeat("c", 600)},
+		Personalizations: []Personalization{
+			{
+				Email:      EmailAddress{Email: "john@example.com"},
+				CustomVars: map[string]string{"campaign": strings.Repeat("o", 900)},
+			},
+			{
+				Email:      EmailAddress{Email: "mary@example.com"},
+				CustomVars: map[string]string{"user_id": "1"},
+			},
+		},
+	}
+	if err := email.Validate(); err != nil {
+		t.Errorf("Validate() returned error: %v", err)
+	}
+
+	email.Personalizations[1].CustomVars["note"] = strings.Repeat("n", 400)
+	want := "'custom_variables' for recipient mary@example.com are greater than 1000 bytes"
+	if err := email.Validate(); err == nil || err.Error() != want {
+		t.Errorf("Validate() returned error %v, want %q", err, want)
+	}
+}
+
+func TestSendEmailRequest_Validate_allErrors(t *testing.T) {
+	email := &SendEmailRequest{
+		From:     EmailAddress{Email: "test@example"},
+		To:       []EmailAddress{{Email: "john@example.com"}, {Email: "mary"}},
 		Text:     "Test",
 		Category: strings.Repeat("c", 260),
 	}
 
-	_, _, err := client.Send(email)
-	if err.Error() != "'category' is greater than 255 chars" {
-		t.Errorf("SendEmail.Send returned error: %v", err)
+	want := ValidationErrors{
+		{Field: "from.email", Message: "'from' address has an invalid domain"},
+		{Field: "to[1].email", Message: "'email' is invalid in 'to' address"},
+		{Field: "subject", Message: "'subject' is required"},
+		{Field: "category", Message: "'category' is greater than 255 chars"},
+	}
+	var errs ValidationErrors
+	if err := email.Validate(); !errors.As(err, &errs) {
+		t.Fatalf("Validate() returned error %v, want ValidationErrors", err)
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("Validate() returned %+v, expected %+v", errs, want)
+	}
+
+	client, _, teardown := setupSandboxSendingClient()
+	defer teardown()
+	_, _, err := client.Send(context.Background(), email)
+	if !errors.As(err, &errs) || !reflect.DeepEqual(errs, want) {
+		t.Errorf("SandboxSendingClient.Send returned error %v, want %v", err, want)
+	}
+}
+
+func TestSendEmailService_Send_sendAtInPast(t *testing.T) {
+	client, mux, teardown := setupSendingClient()
+	defer teardown()
+
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Send made a request with 'send_at' in the past")
+	})
+
+	email := emailRequestMock()
+	sendAt := time.Now().Add(-1 * time.Minute)
+	email.SendAt = &sendAt
+
+	_, _, err := client.Send(context.Background(), email)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("SendEmail.Send returned error %v, want ValidationErrors", err)
+	}
+	want := ValidationErrors{{Field: "send_at", Message: "'send_at' must be in the future"}}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("SendEmail.Send returned %+v, expected %+v", errs, want)
+	}
+
+	sendAt = time.Now().Add(time.Hour)
+	if err := email.Validate(); err != nil {
+		t.Errorf("Validate() returned error: %v", err)
+	}
+}
+
+func TestSendEmailRequest_Validate_template(t *testing.T) {
+	email := &SendEmailRequest{
+		From:         EmailAddress{Email: "test@example.com"},
+		To:           []EmailAddress{{Email: "email@example.com"}},
+		TemplateUUID: "b7ca5d3c-1f6e-4d0a-9c3e-2a4b6c8d0e1f",
+	}
+	if err := email.Validate(); err != nil {
+		t.Errorf("Validate() returned error: %v", err)
+	}
+
+	email.TemplateUUID = "  "
+	var errs ValidationErrors
+	if err := email.Validate(); !errors.As(err, &errs) {
+		t.Fatalf("Validate() returned error %v, want ValidationErrors", err)
+	}
+	want := ValidationErrors{{Field: "template_uuid", Message: "'template_uuid' must not be blank"}}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("Validate() returned %+v, expected %+v", errs, want)
+	}
+}
+
+func TestSendEmailRequest_MarshalJSON_template(t *testing.T) {
+	req := &SendEmailRequest{
+		From:              EmailAddress{Email: "ches@example.com", Name: "Ches"},
+		To:                []EmailAddress{{Email: "john@example.com"}},
+		TemplateUUID:      "b7ca5d3c-1f6e-4d0a-9c3e-2a4b6c8d0e1f",
+		TemplateVariables: map[string]interface{}{"user_name": "John", "order": map[string]interface{}{"total": 42.5}},
+		Subject:           "Ignored",
+		Text:              "Ignored",
+		HTML:              "<p>Ignored</p>",
+	}
+	want := `{
+	  "from": {"email": "ches@example.com", "name": "Ches"},
+	  "to": [{"email": "john@example.com", "name": ""}],
+	  "category": "",
+	  "template_uuid": "b7ca5d3c-1f6e-4d0a-9c3e-2a4b6c8d0e1f",
+	  "template_variables": {"user_name": "John", "order": {"total": 42.5}}
+	}`
+	testJSONMarshal(t, req, want)
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	for _, s := range []string{`"template_uuid":"b7ca5d3c-1f6e-4d0a-9c3e-2a4b6c8d0e1f"`, `"template_variables":{"order":{"total":42.5},"user_name":"John"}`} {
+		if !strings.Contains(string(data), s) {
+			t.Errorf("json.Marshal returned %s, want it to contain %s", data, s)
+		}
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	for _, key := range []string{"subject", "text", "html"} {
+		if _, ok := got[key]; ok {
+			t.Errorf("json.Marshal returned %s, want no %q key", data, key)
+		}
+	}
+}
+
+func TestSendEmailService_Send_missedSubject(t *testing.T) {
+	client, _, teardown := setupSendingClient()
+	defer teardown()
+
+	email := &SendEmailRequest{
+		From:    EmailAddress{Email: "test@example.com"},
+		To:      []EmailAddress{{Email: "email@example.com"}},
+		Subject: "",
+		Text:    "Test",
+	}
+
+	_, _, err := client.Send(context.Background(), email)
+	if err.Error() != "'subject' is required" {
+		t.Errorf("SendEmail.Send returned error: %v", err)
+	}
+}
+
+func TestSendEmailService_Send_validationErrors(t *testing.T) {
+	client, _, teardown := setupSendingClient()
+	defer teardown()
+
+	email := &SendEmailRequest{
+		From: EmailAddress{Email: "test@example.com"},
+		To:   []EmailAddress{{Email: "email@example.com"}},
+		HTML: "",
+	}
+
+	_, _, err := client.Send(context.Background(), email)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("SendEmail.Send returned error %v, want ValidationErrors", err)
+	}
+	want := ValidationErrors{
+		{Field: "subject", Message: "'subject' is required"},
+		{Field: "text", Message: "one of 'text' or 'html' is required"},
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("SendEmail.Send returned %+v, expected %+v", errs, want)
+	}
+	if want := "'subject' is required; one of 'text' or 'html' is required"; err.Error() != want {
+		t.Errorf("SendEmail.Send returned error %q, want %q", err, want)
+	}
+
+	var first ValidationError
+	if !errors.As(err, &first) || first.Field != "subject" {
+		t.Errorf("errors.As(ValidationError) = %+v, want the 'subject' error", first)
+	}
+}
+
+func TestSendEmailService_Send_textOrHTMLRequired(t *testing.T) {
+	client, _, teardown := setupSendingClient()
+	defer teardown()
+
+	email := &SendEmailRequest{
+		From:    EmailAddress{Email: "test@example.com"},
+		To:      []EmailAddress{{Email: "email@example.com"}},
+		Subject: "Subj.",
+	}
+
+	_, _, err := client.Send(context.Background(), email)
+	if err.Error() != "one of 'text' or 'html' is required" {
+		t.Errorf("SendEmail.Send returned error: %v", err)
+	}
+}
+
+func TestSendEmailService_Send_categoryTooLong(t *testing.T) {
+	client, _, teardown := setupSendingClient()
+	defer teardown()
+
+	email := &SendEmailRequest{
+		From:     EmailAddress{Email: "test@example.com"},
+		To:       []EmailAddress{{Email: "email@example.com"}},
+		Subject:  "Subj.",
+		Text:     "Test",
+		Category: strings.Repeat("c", 260),
+	}
+
+	_, _, err := client.Send(context.Background(), email)
+	if err.Error() != "'category' is greater than 255 chars" {
+		t.Errorf("SendEmail.Send returned error: %v", err)
+	}
+}
+
+func TestSendEmailService_Send_headerLimits(t *testing.T) {
+	client, mux, teardown := setupSendingClient()
+	defer teardown()
+
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true,"message_ids":["1"]}`)
+	})
+
+	keyAtLimit := "X-" + strings.Repeat("k", MaxHeaderKeyLength-2)
+	keyOverLimit := keyAtLimit + "k"
+	manyHeaders := map[HeaderName]string{}
+	for i := 0; i <= MaxHeaderCount; i++ {
+		manyHeaders[HeaderName(fmt.Sprintf("X-Header-%d", i))] = "value"
+	}
+
+	tests := []struct {
+		name    string
+		headers map[HeaderName]string
+		wantErr string
+	}{
+		{
+			name:    "key at limit",
+			headers: map[HeaderName]string{HeaderName(keyAtLimit): "value"},
+		},
+		{
+			name:    "key over limit",
+			headers: map[HeaderName]string{HeaderName(keyOverLimit): "value"},
+			wantErr: fmt.Sprintf("header '%s' name is greater than 64 chars", keyOverLimit),
+		},
+		{
+			name:    "value over limit",
+			headers: map[HeaderName]string{HeaderXMailer: strings.Repeat("v", MaxHeaderValueLength+1)},
+			wantErr: "header 'X-Mailer' value is greater than 1000 chars",
+		},
+		{
+			name:    "too many headers",
+			headers: manyHeaders,
+			wantErr: "'headers' cannot have more than 25 headers",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email := &SendEmailRequest{
+				From:    EmailAddress{Email: "test@example.com"},
+				To:      []EmailAddress{{Email: "email@example.com"}},
+				Subject: "Subj.",
+				Text:    "Test",
+				Headers: tt.headers,
+			}
+
+			_, _, err := client.Send(context.Background(), email)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("SendEmail.Send returned error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("SendEmail.Send returned error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSendEmailService_Send_autoHTMLFromText(t *testing.T) {
+	client, mux, teardown := setupSendingClient(WithAutoHTMLFromText())
+	defer teardown()
+
+	var got SendEmailRequest
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("Unable to decode request body: %v", err)
+		}
+		fmt.Fprint(w, `{"success":true,"message_ids":["1"]}`)
+	})
+
+	email := &SendEmailRequest{
+		From:    EmailAddress{Email: "test@example.com"},
+		To:      []EmailAddress{{Email: "email@example.com"}},
+		Subject: "Subj.",
+		Text:    "Hello <world> & co",
+	}
+	if _, _, err := client.Send(context.Background(), email); err != nil {
+		t.Fatalf("SendEmail.Send returned error: %v", err)
+	}
+
+	want := "<html><body><pre>Hello &lt;world&gt; &amp; co</pre></body></html>"
+	if email.HTML != want {
+		t.Errorf("SendEmail.Send HTML = %q, want %q", email.HTML, want)
+	}
+	if got.HTML != want {
+		t.Errorf("SendEmail.Send sent HTML = %q, want %q", got.HTML, want)
+	}
+
+	email.HTML = "<p>Custom</p>"
+	if _, _, err := client.Send(context.Background(), email); err != nil {
+		t.Fatalf("SendEmail.Send returned error: %v", err)
+	}
+	if email.HTML != "<p>Custom</p>" {
+		t.Errorf("SendEmail.Send overwrote HTML with %q", email.HTML)
+	}
+}
+
+func TestSendEmailService_Send_autoHTMLFromTextDisabled(t *testing.T) {
+	client, mux, teardown := setupSendingClient()
+	defer teardown()
+
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true,"message_ids":["1"]}`)
+	})
+
+	email := &SendEmailRequest{
+		From:    EmailAddress{Email: "test@example.com"},
+		To:      []EmailAddress{{Email: "email@example.com"}},
+		Subject: "Subj.",
+		Text:    "Hello",
+	}
+	if _, _, err := client.Send(context.Background(), email); err != nil {
+		t.Fatalf("SendEmail.Send returned error: %v", err)
+	}
+	if email.HTML != "" {
+		t.Errorf("SendEmail.Send HTML = %q, want empty", email.HTML)
+	}
+}
+
+func TestSendEmailService_Send_autoTextFallback(t *testing.T) {
+	for name, newClient := range map[string]func(...Option) (SendingClient, *http.ServeMux, func()){
+		"production": setupSendingClient,
+		"sandbox":    setupSandboxSendingClient,
+	} {
+		t.Run(name, func(t *testing.T) {
+			client, mux, teardown := newClient(WithAutoTextFallback())
+			defer teardown()
+
+			var got SendEmailRequest
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("Unable to decode request body: %v", err)
+				}
+				fmt.Fprint(w, `{"success":true,"message_ids":["1"]}`)
+			})
+
+			email := &SendEmailRequest{
+				From:    EmailAddress{Email: "test@example.com"},
+				To:      []EmailAddress{{Email: "email@example.com"}},
+				Subject: "Subj.",
+				HTML:    "<p>Hello &amp; welcome</p>",
+			}
+			if _, _, err := client.Send(context.Background(), email); err != nil {
+				t.Fatalf("SendEmail.Send returned error: %v", err)
+			}
+			if want := "Hello & welcome"; got.Text != want {
+				t.Errorf("SendEmail.Send sent Text = %q, want %q", got.Text, want)
+			}
+
+			email.Text = "Custom"
+			if _, _, err := client.Send(context.Background(), email); err != nil {
+				t.Fatalf("SendEmail.Send returned error: %v", err)
+			}
+			if got.Text != "Custom" {
+				t.Errorf("SendEmail.Send overwrote Text with %q", got.Text)
+			}
+
+			email = &SendEmailRequest{
+				From:    EmailAddress{Email: "test@example.com"},
+				To:      []EmailAddress{{Email: "email@example.com"}},
+				Subject: "Subj.",
+				Text:    "Text only",
+			}
+			if _, _, err := client.Send(context.Background(), email); err != nil {
+				t.Fatalf("SendEmail.Send returned error: %v", err)
+			}
+			if got.Text != "Text only" || got.HTML != "" {
+				t.Errorf("SendEmail.Send sent Text = %q, HTML = %q, want unchanged", got.Text, got.HTML)
+			}
+		})
+	}
+}
+
+func TestSendEmailService_SendHTMLOnly(t *testing.T) {
+	client, mux, teardown := setupSendingClient()
+	defer teardown()
+
+	c, ok := client.(*ProductionSendingClient)
+	if !ok {
+		t.Fatal("SendEmail.SendHTMLOnly sc is not ProductionSendingClient")
+	}
+
+	var got SendEmailRequest
+	mux.HandleFunc("/send", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("Unable to decode request body: %v", err)
+		}
+		fmt.Fprint(w, `{"success":true,"message_ids":["1"]}`)
+	})
+
+	html := "<h1>Hello</h1><p>World &amp; co</p>"
+	_, _, err := c.SendHTMLOnly(context.Background(),
+		EmailAddress{Email: "test@example.com"}, []EmailAddress{{Email: "email@example.com"}}, "Subj.", html)
+	if err != nil {
+		t.Fatalf("SendEmail.SendHTMLOnly returned error: %v", err)
+	}
+	if got.HTML != html {
+		t.Errorf("SendEmail.SendHTMLOnly sent HTML = %q, want %q", got.HTML, html)
+	}
+	if want := "Hello\nWorld & co"; got.Text != want {
+		t.Errorf("SendEmail.SendHTMLOnly sent Text = %q, want %q", got.Text, want)
+	}
+
+	for _, html := range []string{"", "  "} {
+		_, _, err := c.SendHTMLOnly(context.Background(),
+			EmailAddress{Email: "test@example.com"}, []EmailAddress{{Email: "email@example.com"}}, "Subj.", html)
+		var verrs ValidationErrors
+		if !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Field != "html" || err.Error() != "'html' is required" {
+			t.Errorf("SendEmail.SendHTMLOnly(%q) returned error %v, want validation error", html, err)
+		}
+	}
+}
+
+func TestStripHTMLForText(t *testing.T) {
+	tests := map[string]string{
+		"":                                    "",
+		"plain":                               "plain",
+		"<p>Hello,</p><p>World</p>":           "Hello,\nWorld",
+		"Line 1<br>Line 2<br/>Line 3":         "Line 1\nLine 2\nLine 3",
+		"<b>bold</b>   and&nbsp;<i>it</i>":    "bold and it",
+		"<style>p{color:red}</style><p>x</p>": "x",
+		"<script>alert(1)</script>Hi &lt;3":   "Hi <3",
+		"<div>a</div>\n\n\n\n<div>b</div>":    "a\n\nb",
+	}
+	for in, want := range tests {
+		if got := StripHTMLForText(in); got != want {
+			t.Errorf("StripHTMLForText(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSendEmailService_GetDeliveryStatus(t *testing.T) {
+	client, mux, teardown := setupSendingClient()
+	defer teardown()
+
+	c, ok := client.(*ProductionSendingClient)
+	if !ok {
+		t.Fatal("SendEmail.GetDeliveryStatus sc is not ProductionSendingClient")
+	}
+
+	mux.HandleFunc("/messages/delivered-id", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"message_id":"delivered-id","status":"delivered","delivered_at":"2023-02-14T19:29:59Z"}`)
+	})
+	mux.HandleFunc("/messages/bounced-id", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"message_id":"bounced-id","status":"bounced","bounced_at":"2023-02-14T19:30:00Z","bounce_reason":"mailbox full"}`)
+	})
+	mux.HandleFunc("/messages/queued-id", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"message_id":"queued-id","status":"queued"}`)
+	})
+
+	deliveredAt := time.Date(2023, 2, 14, 19, 29, 59, 0, time.UTC)
+	bouncedAt := time.Date(2023, 2, 14, 19, 30, 0, 0, time.UTC)
+	tests := map[string]*DeliveryStatus{
+		"delivered-id": {MessageID: "delivered-id", Status: "delivered", DeliveredAt: &deliveredAt},
+		"bounced-id":   {MessageID: "bounced-id", Status: "bounced", BouncedAt: &bouncedAt, BounceReason: "mailbox full"},
+		"queued-id":    {MessageID: "queued-id", Status: "queued"},
+	}
+	for id, want := range tests {
+		status, _, err := c.GetDeliveryStatus(context.Background(), id)
+		if err != nil {
+			t.Errorf("SendEmail.GetDeliveryStatus(%q) returned error: %v", id, err)
+		}
+		if !reflect.DeepEqual(status, want) {
+			t.Errorf("SendEmail.GetDeliveryStatus(%q) returned %+v, want %+v", id, status, want)
+		}
+	}
+
+	testBadPathParams(t, "SendEmail.GetDeliveryStatus", func() error {
+		_, _, err := c.GetDeliveryStatus(context.Background(), "")
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "SendEmail.GetDeliveryStatus", &c.client, func() (*Response, error) {
+		status, resp, err := c.GetDeliveryStatus(context.Background(), "queued-id")
+		if status != nil {
+			t.Errorf("SendEmail.GetDeliveryStatus client.BaseURL.Host=%v status=%#v, want nil", c.baseURL.Host, status)
+		}
+		return resp, err
+	})
+}
+
+func TestSendEmailService_GetAccountInfo(t *testing.T) {
+	client, mux, teardown := setupSendingClient()
+	defer teardown()
+
+	mux.HandleFunc("/account", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Authorization", "Bearer api-token")
+		fmt.Fprint(w, `{"account_id":1,"plan":"Business","emails_sent_today":120,"daily_limit":5000}`)
+	})
+
+	v, ok := client.(Verifier)
+	if !ok {
+		t.Fatalf("Sending client is %T, want Verifier", client)
+	}
+
+	info, _, err := v.GetAccountInfo(context.Background())
+	if err != nil {
+		t.Errorf("SendEmail.GetAccountInfo returned error: %v", err)
+	}
+
+	want := &AccountInfo{AccountID: 1, Plan: "Business", EmailsSentToday: 120, DailyLimit: 5000}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("SendEmail.GetAccountInfo returned %+v, want %+v", info, want)
+	}
+}
+
+func TestSendEmailService_GetAccountInfo_unauthorized(t *testing.T) {
+	client, mux, teardown := setupSendingClient()
+	defer teardown()
+
+	mux.HandleFunc("/account", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"errors":["Unauthorized"]}`)
+	})
+
+	info, resp, err := client.(Verifier).GetAccountInfo(context.Background())
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("SendEmail.GetAccountInfo returned error %v, want %v", err, ErrUnauthorized)
+	}
+	if info != nil {
+		t.Errorf("SendEmail.GetAccountInfo returned %+v, want nil", info)
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("SendEmail.GetAccountInfo returned response %+v, want 401", resp)
+	}
+}
+
+func TestSendEmailResponse_MessageIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		resp    *SendEmailResponse
+		wantID  string
+		wantOK  bool
+		wantAll []string
+	}{
+		{"nil", nil, "", false, nil},
+		{"empty", &SendEmailResponse{Success: true}, "", false, nil},
+		{"single", &SendEmailResponse{MessageIDs: []string{"a"}}, "a", true, []string{"a"}},
+		{"multiple", &SendEmailResponse{MessageIDs: []string{"a", "b"}}, "a", true, []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := tt.resp.FirstMessageID()
+			if id != tt.wantID || ok != tt.wantOK {
+				t.Errorf("FirstMessageID() = %q, %v, want %q, %v", id, ok, tt.wantID, tt.wantOK)
+			}
+			if all := tt.resp.AllMessageIDs(); !reflect.DeepEqual(all, tt.wantAll) {
+				t.Errorf("AllMessageIDs() = %v, want %v", all, tt.wantAll)
+			}
+		})
+	}
+}
+
+func TestSendEmailResponse_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		wantErrors     bool
+		wantSuccessful int
+	}{
+		{
+			name:           "full success",
+			body:           `{"success":true,"message_ids":["a","b"]}`,
+			wantSuccessful: 2,
+		},
+		{
+			name: "full failure",
+			body: `{"success":false,"message_ids":[],"errors":[
+				{"email":"a@example.com","error_code":"invalid_recipient","message":"Invalid recipient"},
+				{"email":"b@example.com","error_code":"suppressed","message":"Recipient is suppressed"}]}`,
+			wantErrors: true,
+		},
+		{
+			name: "partial success",
+			body: `{"success":true,"message_ids":["a"],"errors":[
+				{"email":"b@example.com","error_code":"suppressed","message":"Recipient is suppressed"}]}`,
+			wantErrors:     true,
+			wantSuccessful: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp SendEmailResponse
+			if err := json.Unmarshal([]byte(tt.body), &resp); err != nil {
+				t.Fatalf("json.Unmarshal returned error: %v", err)
+			}
+			if got := resp.HasErrors(); got != tt.wantErrors {
+				t.Errorf("HasErrors() = %v, want %v", got, tt.wantErrors)
+			}
+			if got := resp.SuccessfulCount(); got != tt.wantSuccessful {
+				t.Errorf("SuccessfulCount() = %d, want %d", got, tt.wantSuccessful)
+			}
+		})
+	}
+
+	partial := SendEmailResponse{}
+	_ = json.Unmarshal([]byte(tests[2].body), &partial)
+	want := []SendError{{Email: "b@example.com", ErrorCode: "suppressed", Message: "Recipient is suppressed"}}
+	if !reflect.DeepEqual(partial.Errors, want) {
+		t.Errorf("Errors = %+v, want %+v", partial.Errors, want)
+	}
+
+	var nilResp *SendEmailResponse
+	if nilResp.HasErrors() || nilResp.SuccessfulCount() != 0 {
+		t.Error("nil response reported errors or successful messages")
+	}
+}
+
+func TestSendEmailRequest_WithIfEmpty(t *testing.T) {
+	req := (&SendEmailRequest{}).
+		WithCategoryIfEmpty("Default").
+		WithSubjectIfEmpty("Default subject").
+		WithTextIfEmpty("Default text").
+		WithHTMLIfEmpty("<p>Default</p>")
+
+	want := &SendEmailRequest{
+		Category: "Default",
+		Subject:  "Default subject",
+		Text:     "Default text",
+		HTML:     "<p>Default</p>",
+	}
+	if !reflect.DeepEqual(req, want) {
+		t.Errorf("With*IfEmpty on empty request = %+v, want %+v", req, want)
+	}
+
+	req = &SendEmailRequest{Category: "App", Subject: "App subject", Text: "App text", HTML: "<p>App</p>"}
+	want = &SendEmailRequest{Category: "App", Subject: "App subject", Text: "App text", HTML: "<p>App</p>"}
+	req.WithCategoryIfEmpty("Default").
+		WithSubjectIfEmpty("Default subject").
+		WithTextIfEmpty("Default text").
+		WithHTMLIfEmpty("<p>Default</p>")
+	if !reflect.DeepEqual(req, want) {
+		t.Errorf("With*IfEmpty on filled request = %+v, want %+v", req, want)
+	}
+}
+
+func TestSendEmailRequest_WithContent(t *testing.T) {
+	req := &SendEmailRequest{}
+	if _, err := req.WithContent("<p>First</p>", "text/html"); err != nil {
+		t.Errorf("WithContent returned error: %v", err)
+	}
+	if _, err := req.WithContent("Plain", "text/plain; charset=utf-8"); err != nil {
+		t.Errorf("WithContent returned error: %v", err)
+	}
+	if _, err := req.WithContent("<p>Second</p>", "text/html"); err != nil {
+		t.Errorf("WithContent returned error: %v", err)
+	}
+
+	want := &SendEmailRequest{HTML: "<p>Second</p>", Text: "Plain"}
+	if !reflect.DeepEqual(req, want) {
+		t.Errorf("WithContent = %+v, want %+v", req, want)
+	}
+
+	for _, mediaType := range []string{"application/json", ""} {
+		if _, err := req.WithContent("{}", mediaType); err == nil {
+			t.Errorf("WithContent(%q) returned no error", mediaType)
+		}
+	}
+	if !reflect.DeepEqual(req, want) {
+		t.Errorf("WithContent with unsupported media type modified the request: %+v", req)
+	}
+}
+
+func TestSendEmailRequestToMap(t *testing.T) {
+	req := emailRequestMock()
+	req.HTML = "<p>Congratulations on your order no.123</p>"
+	req.Personalizations = []Personalization{
+		{Email: EmailAddress{Email: "mike@example.com"}, CustomVars: map[string]string{"user_id": "2"}},
+	}
+
+	m, err := SendEmailRequestToMap(req)
+	if err != nil {
+		t.Fatalf("SendEmailRequestToMap returned error: %v", err)
+	}
+	if m["subject"] != req.Subject {
+		t.Errorf("SendEmailRequestToMap subject = %v, want %q", m["subject"], req.Subject)
+	}
+
+	got, err := ParseSendEmailRequestFromMap(m)
+	if err != nil {
+		t.Fatalf("ParseSendEmailRequestFromMap returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, req) {
+		t.Errorf("ParseSendEmailRequestFromMap = %+v, want %+v", got, req)
+	}
+
+	if _, err := SendEmailRequestToMap(nil); err == nil {
+		t.Error("SendEmailRequestToMap(nil) returned no error")
+	}
+}
+
+func TestParseSendEmailRequestFromMap_errors(t *testing.T) {
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"from":    map[string]interface{}{"email": "ches@example.com"},
+			"to":      []interface{}{map[string]interface{}{"email": "john@example.com"}},
+			"subject": "Hello",
+			"text":    "Hello, world!",
+		}
+	}
+	if _, err := ParseSendEmailRequestFromMap(valid()); err != nil {
+		t.Fatalf("ParseSendEmailRequestFromMap returned error: %v", err)
+	}
+
+	tests := map[string]struct {
+		modify func(map[string]interface{})
+		want   string
+	}{
+		"missing from": {
+			func(m map[string]interface{}) { delete(m, "from") },
+			`parse send email request: missing required key "from"`,
+		},
+		"missing to": {
+			func(m map[string]interface{}) { delete(m, "to") },
+			`parse send email request: missing required key "to"`,
+		},
+		"missing subject": {
+			func(m map[string]interface{}) { delete(m, "subject") },
+			`parse send email request: missing required key "subject"`,
+		},
+		"missing body": {
+			func(m map[string]interface{}) { delete(m, "text") },
+			`parse send email request: missing required key "text" or "html"`,
+		},
+		"wrong type": {
+			func(m map[string]interface{}) { m["to"] = "john@example.com" },
+			"parse send email request: json: cannot unmarshal string",
+		},
+	}
+	for name, tt := range tests {
+		m := valid()
+		tt.modify(m)
+		if _, err := ParseSendEmailRequestFromMap(m); err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("%s: ParseSendEmailRequestFromMap returned error %v, want prefix %q", name, err, tt.want)
+		}
+	}
+}
+
+func TestParseSendEmailRequestFromMap_template(t *testing.T) {
+	m := map[string]interface{}{
+		"from":               map[string]interface{}{"email": "ches@example.com"},
+		"to":                 []interface{}{map[string]interface{}{"email": "john@example.com"}},
+		"template_uuid":      "b7ca5d3c-1f6e-4d0a-9c3e-2a4b6c8d0e1f",
+		"template_variables": map[string]interface{}{"user_name": "John"},
+	}
+	got, err := ParseSendEmailRequestFromMap(m)
+	if err != nil {
+		t.Fatalf("ParseSendEmailRequestFromMap returned error: %v", err)
+	}
+
+	want := &SendEmailRequest{
+		From:              EmailAddress{Email: "ches@example.com"},
+		To:                []EmailAddress{{Email: "john@example.com"}},
+		TemplateUUID:      "b7ca5d3c-1f6e-4d0a-9c3e-2a4b6c8d0e1f",
+		TemplateVariables: map[string]interface{}{"user_name": "John"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseSendEmailRequestFromMap returned %+v, expected %+v", got, want)
+	}
+}
+
+func TestRedactEmailAddress(t *testing.T) {
+	tests := map[string]string{
+		"john@example.com":       "j***@example.com",
+		"j@example.com":          "j***@example.com",
+		"john.doe+tag@mail.a.io": "j***@mail.a.io",
+		"weird@name@example.com": "w***@example.com",
+		"@example.com":           "***",
+		"no-at-sign":             "***",
+		"":                       "",
+	}
+	for addr, want := range tests {
+		if got := RedactEmailAddress(addr); got != want {
+			t.Errorf("RedactEmailAddress(%q) = %q, want %q", addr, got, want)
+		}
+	}
+}
+
+func TestSendEmailRequest_ToLogSafeString(t *testing.T) {
+	req := emailRequestMock()
+	req.HTML = "<p>Secret HTML body</p>"
+
+	got := req.ToLogSafeString()
+	want := `{"from":"c***@example.com","to":["j***@example.com","m***@example.com"],` +
+		`"cc":["i***@example.com"],"bcc":["d***@example.com"],` +
+		`"subject":"Your Example Order Confirmation","category":"API Client","attachments":["index.html"]}`
+	if got != want {
+		t.Errorf("ToLogSafeString() = %s, want %s", got, want)
+	}
+
+	for _, secret := range []string{req.Text, req.HTML, "user_id", "batch_id", "johndoe", "Ches"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("ToLogSafeString() = %s, must not contain %q", got, secret)
+		}
+	}
+}
+
+func TestEmailAddress_Redact(t *testing.T) {
+	tests := []struct {
+		addr, want EmailAddress
+	}{
+		{EmailAddress{Email: "john@example.com", Name: "John"}, EmailAddress{Email: "j***@example.com", Name: "J"}},
+		{EmailAddress{Email: "j@example.com"}, EmailAddress{Email: "j***@example.com"}},
+		{EmailAddress{Email: "john.doe@mail.example.com", Name: "John  van der Doe"}, EmailAddress{Email: "j***@mail.example.com", Name: "JVDD"}},
+		{EmailAddress{Email: "ólafur@example.is", Name: "ólafur Arnalds"}, EmailAddress{Email: "ó***@example.is", Name: "ÓA"}},
+		{EmailAddress{}, EmailAddress{}},
+	}
+	for _, tt := range tests {
+		if got := tt.addr.Redact(); got != tt.want {
+			t.Errorf("%+v.Redact() = %+v, want %+v", tt.addr, got, tt.want)
+		}
+	}
+
+	addrs := []EmailAddress{{Email: "john@example.com", Name: "John Doe"}, {Email: "mary@example.com"}}
+	want := []EmailAddress{{Email: "j***@example.com", Name: "JD"}, {Email: "m***@example.com"}}
+	if got := RedactEmailAddresses(addrs); !reflect.DeepEqual(got, want) {
+		t.Errorf("RedactEmailAddresses(%+v) = %+v, want %+v", addrs, got, want)
+	}
+	if addrs[0].Email != "john@example.com" {
+		t.Errorf("RedactEmailAddresses modified its argument: %+v", addrs)
+	}
+}
+
+func TestSendEmailRequest_NormalizeEmails(t *testing.T) {
+	req := &SendEmailRequest{
+		From: EmailAddress{Email: "Ches@Example.COM", Name: "Ches Martin"},
+		To:   []EmailAddress{{Email: "John.Doe@Example.com", Name: "John Doe"}},
+		Cc:   []EmailAddress{{Email: "INFO@example.com"}},
+		Bcc:  []EmailAddress{{Email: "audit@example.com", Name: "Audit"}},
+
+		ReplyTo: []EmailAddress{{Email: "Support@Example.com"}},
+	}
+
+	want := &SendEmailRequest{
+		From: EmailAddress{Email: "ches@example.com", Name: "Ches Martin"},
+		To:   []EmailAddress{{Email: "john.doe@example.com", Name: "John Doe"}},
+		Cc:   []EmailAddress{{Email: "info@example.com"}},
+		Bcc:  []EmailAddress{{Email: "audit@example.com", Name: "Audit"}},
+
+		ReplyTo: []EmailAddress{{Email: "support@example.com"}},
+	}
+	if got := req.NormalizeEmails(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeEmails() = %+v, want %+v", got, want)
+	}
+	if got := req.NormalizeEmails(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeEmails() second call = %+v, want %+v", got, want)
+	}
+
+	empty := &SendEmailRequest{From: EmailAddress{Email: "A@B.C"}}
+	if got := empty.NormalizeEmails(); got.From.Email != "a@b.c" || got.To != nil || got.Cc != nil || got.Bcc != nil {
+		t.Errorf("NormalizeEmails() with nil slices = %+v", got)
+	}
+}
+
+func TestNewReplyAllRequest(t *testing.T) {
+	original := &Message{
+		Subject:   "Re: RE: Quarterly report",
+		FromEmail: "john@example.com",
+		FromName:  "John",
+		ToEmail:   "mary@example.com, John@example.com, bob@example.com, mary@example.com",
+		ToName:    "Mary, John, Bob, Mary",
+		MessageID: "<abc@example.com>",
+	}
+
+	want := &SendEmailRequest{
+		To: []EmailAddress{{Email: "john@example.com", Name: "John"}},
+		Cc: []EmailAddress{
+			{Email: "mary@example.com", Name: "Mary"},
+			{Email: "bob@example.com", Name: "Bob"},
+		},
+		Subject: "Re: Quarterly report",
+		Headers: map[HeaderName]string{
+			HeaderInReplyTo:  "<abc@example.com>",
+			HeaderReferences: "<abc@example.com>",
+		},
+	}
+	if got := NewReplyAllRequest(original); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewReplyAllRequest() = %+v, want %+v", got, want)
+	}
+
+	got := NewReplyAllRequest(&Message{Subject: "Hello", FromEmail: "john@example.com", ToEmail: "john@example.com"})
+	if got.Subject != "Re: Hello" {
+		t.Errorf("NewReplyAllRequest() Subject = %q, want %q", got.Subject, "Re: Hello")
+	}
+	if got.Cc != nil {
+		t.Errorf("NewReplyAllRequest() Cc = %+v, want nil", got.Cc)
+	}
+	if got.Headers != nil {
+		t.Errorf("NewReplyAllRequest() Headers = %+v, want nil without a Message-ID", got.Headers)
+	}
+	if got.Text != "" {
+		t.Errorf("NewReplyAllRequest() Text = %q, want blank", got.Text)
+	}
+
+	if got := NewReplyAllRequest(nil); got != nil {
+		t.Errorf("NewReplyAllRequest(nil) = %+v, want nil", got)
+	}
+}
+
+func TestEmailAttachment_EnsureContentID(t *testing.T) {
+	a := &EmailAttachment{Filename: "logo.png", Disposition: "inline"}
+
+	id := a.EnsureContentID()
+	if !regexp.MustCompile(`^<[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}@mailtrap-go>$`).MatchString(id) {
+		t.Errorf("EnsureContentID() = %q, want <uuid@mailtrap-go>", id)
+	}
+	if again := a.EnsureContentID(); again != id {
+		t.Errorf("EnsureContentID() second call = %q, want %q", again, id)
+	}
+
+	a = &EmailAttachment{ContentID: "<logo@example.com>"}
+	if id := a.EnsureContentID(); id != "<logo@example.com>" {
+		t.Errorf("EnsureContentID() = %q, want existing ID", id)
+	}
+}
+
+func TestNewAttachmentFromReader(t *testing.T) {
+	got, err := NewAttachmentFromReader(strings.NewReader("Hello, world!"), "hello.txt", "text/plain")
+	if err != nil {
+		t.Fatalf("NewAttachmentFromReader returned error: %v", err)
+	}
+	want := EmailAttachment{
+		Content:     "SGVsbG8sIHdvcmxkIQ==",
+		AttachType:  "text/plain",
+		Filename:    "hello.txt",
+		Disposition: "attachment",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAttachmentFromReader returned %+v, expected %+v", got, want)
+	}
+
+	if _, err := NewAttachmentFromReader(nil, "hello.txt", "text/plain"); err == nil {
+		t.Error("NewAttachmentFromReader with nil reader err = nil, want error")
+	}
+	readErr := errors.New("disk failure")
+	if _, err := NewAttachmentFromReader(iotest.ErrReader(readErr), "hello.txt", "text/plain"); !errors.Is(err, readErr) {
+		t.Errorf("NewAttachmentFromReader returned error %v, want %v", err, readErr)
+	}
+}
+
+func TestNewInlineAttachmentFromReader(t *testing.T) {
+	got, err := NewInlineAttachmentFromReader(bytes.NewReader([]byte{0x89, 'P', 'N', 'G'}), "logo.png", "image/png")
+	if err != nil {
+		t.Fatalf("NewInlineAttachmentFromReader returned error: %v", err)
+	}
+	if got.Content != "iVBORw==" || got.Filename != "logo.png" || got.AttachType != "image/png" {
+		t.Errorf("NewInlineAttachmentFromReader returned %+v", got)
+	}
+	if got.Disposition != "inline" {
+		t.Errorf("NewInlineAttachmentFromReader disposition = %q, want %q", got.Disposition, "inline")
+	}
+	if !contentIDFormat.MatchString(got.ContentID) {
+		t.Errorf("NewInlineAttachmentFromReader content ID = %q, want <id@domain>", got.ContentID)
+	}
+
+	if _, err := NewInlineAttachmentFromReader(nil, "logo.png", "image/png"); err == nil {
+		t.Error("NewInlineAttachmentFromReader with nil reader err = nil, want error")
+	}
+}
+
+func TestNewAttachmentFromFile(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		pattern  string
+		wantType string
+	}{
+		{"report-*.pdf", "application/pdf"},
+		{"data-*.unknownext", "application/octet-stream"},
+	}
+	for _, tt := range tests {
+		f, err := os.CreateTemp(dir, tt.pattern)
+		if err != nil {
+			t.Fatalf("CreateTemp returned error: %v", err)
+		}
+		raw := []byte("%PDF-1.4\n\x00\xff")
+		if _, err := f.Write(raw); err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+		f.Close()
+
+		a, err := NewAttachmentFromFile(f.Name())
+		if err != nil {
+			t.Fatalf("NewAttachmentFromFile returned error: %v", err)
+		}
+		if a.Filename != filepath.Base(f.Name()) || a.AttachType != tt.wantType || a.Disposition != "attachment" {
+			t.Errorf("NewAttachmentFromFile returned %+v, want filename %q and type %q",
+				a, filepath.Base(f.Name()), tt.wantType)
+		}
+		if got, err := a.DecodeContent(); err != nil || !bytes.Equal(got, raw) {
+			t.Errorf("NewAttachmentFromFile content decodes to %q, %v, want %q", got, err, raw)
+		}
+	}
+
+	if _, err := NewAttachmentFromFile(filepath.Join(dir, "missing.pdf")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("NewAttachmentFromFile with a missing file returned error %v, want %v", err, fs.ErrNotExist)
+	}
+
+	large := filepath.Join(dir, "large.bin")
+	if err := os.WriteFile(large, nil, 0o600); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+	if err := os.Truncate(large, MaxAttachmentFileSize+1); err != nil {
+		t.Fatalf("Truncate returned error: %v", err)
+	}
+	if _, err := NewAttachmentFromFile(large); err == nil || !strings.Contains(err.Error(), "larger than") {
+		t.Errorf("NewAttachmentFromFile with a too large file returned error %v, want size limit error", err)
+	}
+}
+
+func TestEmailAttachment_DecodeContent(t *testing.T) {
+	raw := []byte("Hello, \x00world!\n")
+	a := &EmailAttachment{Filename: "hello.bin", Content: base64.StdEncoding.EncodeToString(raw)}
+
+	got, err := a.DecodeContent()
+	if err != nil {
+		t.Fatalf("DecodeContent returned error: %v", err)
+	}
+	if !bytes.Equal(got, raw) {
+		t.Errorf("DecodeContent() = %q, want %q", got, raw)
+	}
+
+	a.Content = "SGVsbG8*"
+	if _, err := a.DecodeContent(); err == nil || !strings.Contains(err.Error(), `"hello.bin"`) {
+		t.Errorf("DecodeContent() with corrupted content err = %v, want error naming the attachment", err)
+	}
+}
+
+func TestSendEmailRequest_EnsureInlineContentIDs(t *testing.T) {
+	req := &SendEmailRequest{
+		Attachments: []EmailAttachment{
+			{Filename: "a.png", Disposition: "inline"},
+			{Filename: "b.png", Disposition: "inline"},
+			{Filename: "c.pdf", Disposition: "attachment"},
+		},
+	}
+
+	req.EnsureInlineContentIDs()
+
+	a, b, c := req.Attachments[0].ContentID, req.Attachments[1].ContentID, req.Attachments[2].ContentID
+	if a == "" || b == "" {
+		t.Fatalf("EnsureInlineContentIDs() left inline IDs empty: %q, %q", a, b)
+	}
+	if a == b {
+		t.Errorf("EnsureInlineContentIDs() generated duplicate IDs: %q", a)
+	}
+	if c != "" {
+		t.Errorf("EnsureInlineContentIDs() set ID %q on a regular attachment", c)
+	}
+
+	req.EnsureInlineContentIDs()
+	if req.Attachments[0].ContentID != a || req.Attachments[1].ContentID != b {
+		t.Error("EnsureInlineContentIDs() is not idempotent")
 	}
 }
 
@@ -225,7 +1995,7 @@ func emailRequestMock() *SendEmailRequest {
 			"user_id":  "1",
 			"batch_id": "2",
 		},
-		Headers: map[string]string{
+		Headers: map[HeaderName]string{
 			"X-Message-Source": "mail.example.com",
 		},
 		Subject:  "Your Example Order Confirmation",
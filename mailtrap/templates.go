@@ -0,0 +1,208 @@
+package mailtrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// TemplatesServiceContract defines the methods available to email templates.
+type TemplatesServiceContract interface {
+	List(accountID int) ([]*Template, *Response, error)
+	ListWithContext(ctx context.Context, accountID int) ([]*Template, *Response, error)
+	Get(accountID, templateID int) (*Template, *Response, error)
+	GetWithContext(ctx context.Context, accountID, templateID int) (*Template, *Response, error)
+	Create(accountID int, createReq *CreateTemplateRequest) (*Template, *Response, error)
+	CreateWithContext(ctx context.Context, accountID int, createReq *CreateTemplateRequest) (*Template, *Response, error)
+	Update(accountID, templateID int, updateReq *UpdateTemplateRequest) (*Template, *Response, error)
+	UpdateWithContext(ctx context.Context, accountID, templateID int, updateReq *UpdateTemplateRequest) (*Template, *Response, error)
+	Delete(accountID, templateID int) (*Response, error)
+	DeleteWithContext(ctx context.Context, accountID, templateID int) (*Response, error)
+	RenderTemplate(ctx context.Context, accountID int, renderReq *RenderTemplateRequest) (*RenderedTemplate, *Response, error)
+}
+
+type TemplatesService struct {
+	client *client
+}
+
+var _ TemplatesServiceContract = &TemplatesService{}
+
+// Template represents a Mailtrap email template.
+type Template struct {
+	ID        int       `json:"id"`
+	UUID      string    `json:"uuid"`
+	Name      string    `json:"name"`
+	Subject   string    `json:"subject"`
+	HTMLBody  string    `json:"html_body"`
+	TextBody  string    `json:"text_body"`
+	Category  string    `json:"category"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateTemplateRequest represents the request to create an email template.
+type CreateTemplateRequest struct {
+	Name     string `json:"name"`
+	Subject  string `json:"subject"`
+	HTMLBody string `json:"html_body,omitempty"`
+	TextBody string `json:"text_body,omitempty"`
+	Category string `json:"category"`
+}
+
+// UpdateTemplateRequest represents the request to update an email template.
+// Only the non-empty fields are updated.
+type UpdateTemplateRequest struct {
+	Name     string `json:"name,omitempty"`
+	Subject  string `json:"subject,omitempty"`
+	HTMLBody string `json:"html_body,omitempty"`
+	TextBody string `json:"text_body,omitempty"`
+	Category string `json:"category,omitempty"`
+}
+
+// RenderTemplateRequest represents the request to preview an email template with sample data.
+type RenderTemplateRequest struct {
+	TemplateUUID string                 `json:"template_uuid"`
+	Variables    map[string]interface{} `json:"template_variables,omitempty"`
+}
+
+// RenderedTemplate represents an email template rendered with sample data.
+type RenderedTemplate struct {
+	Subject  string `json:"subject"`
+	HTMLBody string `json:"html_body"`
+	TextBody string `json:"text_body"`
+
+	// Warnings about the rendering, e.g. variables used by the template but missing in the request.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// List returns all email templates of the account.
+func (s *TemplatesService) List(accountID int) ([]*Template, *Response, error) {
+	return s.ListWithContext(context.Background(), accountID)
+}
+
+// ListWithContext is like List but uses the given context for the request.
+func (s *TemplatesService) ListWithContext(ctx context.Context, accountID int) ([]*Template, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/email_templates", accountID)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var templates []*Template
+	res, err := s.client.Do(ctx, req, &templates)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return templates, res, nil
+}
+
+// Get returns the email template by ID.
+func (s *TemplatesService) Get(accountID, templateID int) (*Template, *Response, error) {
+	return s.GetWithContext(context.Background(), accountID, templateID)
+}
+
+// GetWithContext is like Get but uses the given context for the request.
+func (s *TemplatesService) GetWithContext(ctx context.Context, accountID, templateID int) (*Template, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/email_templates/%d", accountID, templateID)
+	return s.makeRequestWithContext(ctx, u, http.MethodGet, nil)
+}
+
+// Create creates an email template.
+func (s *TemplatesService) Create(accountID int, createReq *CreateTemplateRequest) (*Template, *Response, error) {
+	return s.CreateWithContext(context.Background(), accountID, createReq)
+}
+
+// CreateWithContext is like Create but uses the given context for the request.
+func (s *TemplatesService) CreateWithContext(ctx context.Context, accountID int, createReq *CreateTemplateRequest) (*Template, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/email_templates", accountID)
+	payload := struct {
+		Template *CreateTemplateRequest `json:"email_template"`
+	}{createReq}
+
+	return s.makeRequestWithContext(ctx, u, http.MethodPost, payload)
+}
+
+// Update updates the email template.
+func (s *TemplatesService) Update(
+	accountID, templateID int,
+	updateReq *UpdateTemplateRequest,
+) (*Template, *Response, error) {
+	return s.UpdateWithContext(context.Background(), accountID, templateID, updateReq)
+}
+
+// UpdateWithContext is like Update but uses the given context for the request.
+func (s *TemplatesService) UpdateWithContext(
+	ctx context.Context,
+	accountID, templateID int,
+	updateReq *UpdateTemplateRequest,
+) (*Template, *Response, error) {
+	u := fmt.Sprintf("/accounts/%d/email_templates/%d", accountID, templateID)
+	payload := struct {
+		Template *UpdateTemplateRequest `json:"email_template"`
+	}{updateReq}
+
+	return s.makeRequestWithContext(ctx, u, http.MethodPatch, payload)
+}
+
+// Delete removes the email template.
+func (s *TemplatesService) Delete(accountID, templateID int) (*Response, error) {
+	return s.DeleteWithContext(context.Background(), accountID, templateID)
+}
+
+// DeleteWithContext is like Delete but uses the given context for the request.
+func (s *TemplatesService) DeleteWithContext(ctx context.Context, accountID, templateID int) (*Response, error) {
+	u := fmt.Sprintf("/accounts/%d/email_templates/%d", accountID, templateID)
+	req, err := s.client.NewRequest(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+// RenderTemplate renders the email template with the given variables without sending it.
+func (s *TemplatesService) RenderTemplate(
+	ctx context.Context,
+	accountID int,
+	renderReq *RenderTemplateRequest,
+) (*RenderedTemplate, *Response, error) {
+	if renderReq == nil || renderReq.TemplateUUID == "" {
+		return nil, nil, errors.New("'template_uuid' is required")
+	}
+
+	u := fmt.Sprintf("/accounts/%d/email_templates/render", accountID)
+	req, err := s.client.NewRequest(ctx, http.MethodPost, u, renderReq)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rendered *RenderedTemplate
+	res, err := s.client.Do(ctx, req, &rendered)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return rendered, res, nil
+}
+
+func (s *TemplatesService) makeRequestWithContext(
+	ctx context.Context,
+	endpoint, httpMethod string,
+	payload interface{},
+) (*Template, *Response, error) {
+	req, err := s.client.NewRequest(ctx, httpMethod, endpoint, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var template *Template
+	res, err := s.client.Do(ctx, req, &template)
+	if err != nil {
+		return nil, res, err
+	}
+
+	return template, res, nil
+}
@@ -0,0 +1,274 @@
+package mailtrap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTemplatesService_Marshal(t *testing.T) {
+	testJSONMarshal(t, &Template{}, "{}")
+
+	u := templateMock(1)
+	want := `{
+		"id": 1,
+		"uuid": "813e39db-c74a-4830-b037-0e6ba8b1fe88",
+		"name": "Welcome",
+		"subject": "Welcome aboard",
+		"html_body": "<p>Hello</p>",
+		"text_body": "Hello",
+		"category": "Onboarding",
+		"created_at": "2023-02-14T19:29:59.295Z",
+		"updated_at": "2023-02-14T19:29:59.295Z"
+	}`
+	testJSONMarshal(t, u, want)
+}
+
+func TestTemplatesService_List(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	expectedTemplates := []*Template{templateMock(1), templateMock(2)}
+
+	mux.HandleFunc("/accounts/1/email_templates", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal(expectedTemplates)
+		fmt.Fprint(w, string(resp))
+	})
+
+	templates, _, err := client.Templates.List(1)
+	if err != nil {
+		t.Errorf("Templates.List returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(templates, expectedTemplates) {
+		t.Errorf("Templates.List returned %+v, expected %+v", templates, expectedTemplates)
+	}
+
+	testBadPathParams(t, "Templates.List", func() error {
+		_, _, err = client.Templates.List(-1)
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "Templates.List", &client.client, func() (*Response, error) {
+		tmpl, resp, err := client.Templates.List(1)
+		if tmpl != nil {
+			t.Errorf("Templates.List client.BaseURL.Host=%v tmpl=%#v, want nil", client.baseURL.Host, tmpl)
+		}
+		return resp, err
+	})
+}
+
+func TestTemplatesService_Get(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	expectedTemplate := templateMock(2)
+
+	mux.HandleFunc("/accounts/1/email_templates/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		resp, _ := json.Marshal(expectedTemplate)
+		fmt.Fprint(w, string(resp))
+	})
+
+	tmpl, _, err := client.Templates.Get(1, 2)
+	if err != nil {
+		t.Errorf("Templates.Get returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(tmpl, expectedTemplate) {
+		t.Errorf("Templates.Get returned %+v, expected %+v", tmpl, expectedTemplate)
+	}
+
+	testBadPathParams(t, "Templates.Get", func() error {
+		_, _, err = client.Templates.Get(-1, -2)
+		return err
+	})
+
+	testNewRequestAndDoFail(t, "Templates.Get", &client.client, func() (*Response, error) {
+		tmpl, resp, err := client.Templates.Get(1, 2)
+		if tmpl != nil {
+			t.Errorf("Templates.Get client.BaseURL.Host=%v tmpl=%#v, want nil", client.baseURL.Host, tmpl)
+		}
+		return resp, err
+	})
+}
+
+func TestTemplatesService_Create(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	createReq := &CreateTemplateRequest{
+		Name:     "Welcome",
+		Subject:  "Welcome aboard",
+		HTMLBody: "<p>Hello</p>",
+		TextBody: "Hello",
+		Category: "Onboarding",
+	}
+
+	mux.HandleFunc("/accounts/1/email_templates", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var payload struct {
+			Template *CreateTemplateRequest `json:"email_template"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Unable to decode request body: %v", err)
+		}
+		if !reflect.DeepEqual(payload.Template, createReq) {
+			t.Errorf("Request body = %+v, expected %+v", payload.Template, createReq)
+		}
+		fmt.Fprint(w, `{"id":1,"name":"Welcome"}`)
+	})
+
+	tmpl, _, err := client.Templates.Create(1, createReq)
+	if err != nil {
+		t.Errorf("Templates.Create returned error: %v", err)
+	}
+
+	expected := &Template{ID: 1, Name: "Welcome"}
+	if !reflect.DeepEqual(tmpl, expected) {
+		t.Errorf("Templates.Create returned %+v, expected %+v", tmpl, expected)
+	}
+
+	testNewRequestAndDoFail(t, "Templates.Create", &client.client, func() (*Response, error) {
+		tmpl, resp, err := client.Templates.Create(1, createReq)
+		if tmpl != nil {
+			t.Errorf("Templates.Create client.BaseURL.Host=%v tmpl=%#v, want nil", client.baseURL.Host, tmpl)
+		}
+		return resp, err
+	})
+}
+
+func TestTemplatesService_Update(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/email_templates/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		fmt.Fprint(w, `{"id":2,"name":"Renamed"}`)
+	})
+
+	tmpl, _, err := client.Templates.Update(1, 2, &UpdateTemplateRequest{Name: "Renamed"})
+	if err != nil {
+		t.Errorf("Templates.Update returned error: %v", err)
+	}
+
+	expected := &Template{ID: 2, Name: "Renamed"}
+	if !reflect.DeepEqual(tmpl, expected) {
+		t.Errorf("Templates.Update returned %+v, expected %+v", tmpl, expected)
+	}
+
+	testNewRequestAndDoFail(t, "Templates.Update", &client.client, func() (*Response, error) {
+		tmpl, resp, err := client.Templates.Update(1, 2, &UpdateTemplateRequest{Name: "Renamed"})
+		if tmpl != nil {
+			t.Errorf("Templates.Update client.BaseURL.Host=%v tmpl=%#v, want nil", client.baseURL.Host, tmpl)
+		}
+		return resp, err
+	})
+}
+
+func TestTemplatesService_Delete(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/email_templates/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Templates.Delete(1, 2)
+	if err != nil {
+		t.Errorf("Templates.Delete returned error: %v", err)
+	}
+
+	testNewRequestAndDoFail(t, "Templates.Delete", &client.client, func() (*Response, error) {
+		return client.Templates.Delete(1, 2)
+	})
+}
+
+func TestTemplatesService_RenderTemplate(t *testing.T) {
+	client, mux, teardown := setupTestingClient()
+	defer teardown()
+
+	mux.HandleFunc("/accounts/1/email_templates/render", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var renderReq RenderTemplateRequest
+		if err := json.NewDecoder(r.Body).Decode(&renderReq); err != nil {
+			t.Fatalf("decode request body: %v", err)
+		}
+		switch {
+		case renderReq.TemplateUUID != "813e39db-c74a-4830-b037-0e6ba8b1fe88":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":"Not Found"}`)
+		case renderReq.Variables["name"] == nil:
+			fmt.Fprint(w, `{"subject":"Welcome","html_body":"<p>Hello </p>","text_body":"Hello ",`+
+				`"warnings":["variable 'name' is missing"]}`)
+		default:
+			fmt.Fprintf(w, `{"subject":"Welcome","html_body":"<p>Hello %[1]s</p>","text_body":"Hello %[1]s"}`,
+				renderReq.Variables["name"])
+		}
+	})
+
+	rendered, _, err := client.Templates.RenderTemplate(context.Background(), 1, &RenderTemplateRequest{
+		TemplateUUID: "813e39db-c74a-4830-b037-0e6ba8b1fe88",
+		Variables:    map[string]interface{}{"name": "John"},
+	})
+	if err != nil {
+		t.Errorf("Templates.RenderTemplate returned error: %v", err)
+	}
+	expected := &RenderedTemplate{Subject: "Welcome", HTMLBody: "<p>Hello John</p>", TextBody: "Hello John"}
+	if !reflect.DeepEqual(rendered, expected) {
+		t.Errorf("Templates.RenderTemplate returned %+v, expected %+v", rendered, expected)
+	}
+
+	rendered, _, err = client.Templates.RenderTemplate(context.Background(), 1, &RenderTemplateRequest{
+		TemplateUUID: "813e39db-c74a-4830-b037-0e6ba8b1fe88",
+	})
+	if err != nil {
+		t.Errorf("Templates.RenderTemplate returned error: %v", err)
+	}
+	if want := []string{"variable 'name' is missing"}; !reflect.DeepEqual(rendered.Warnings, want) {
+		t.Errorf("Templates.RenderTemplate returned warnings %v, expected %v", rendered.Warnings, want)
+	}
+
+	_, _, err = client.Templates.RenderTemplate(context.Background(), 1, &RenderTemplateRequest{TemplateUUID: "unknown"})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Templates.RenderTemplate returned error %v, expected %v", err, ErrNotFound)
+	}
+
+	_, _, err = client.Templates.RenderTemplate(context.Background(), 1, &RenderTemplateRequest{})
+	if err == nil || err.Error() != "'template_uuid' is required" {
+		t.Errorf("Templates.RenderTemplate returned error %v, expected 'template_uuid' is required", err)
+	}
+
+	testNewRequestAndDoFail(t, "Templates.RenderTemplate", &client.client, func() (*Response, error) {
+		rendered, resp, err := client.Templates.RenderTemplate(context.Background(), 1, &RenderTemplateRequest{
+			TemplateUUID: "813e39db-c74a-4830-b037-0e6ba8b1fe88",
+		})
+		if rendered != nil {
+			t.Errorf("Templates.RenderTemplate client.BaseURL.Host=%v rendered=%#v, want nil", client.baseURL.Host, rendered)
+		}
+		return resp, err
+	})
+}
+
+func templateMock(ID int) *Template {
+	datetime, _ := time.Parse(time.RFC3339, "2023-02-14T19:29:59.295Z")
+
+	return &Template{
+		ID:        ID,
+		UUID:      "813e39db-c74a-4830-b037-0e6ba8b1fe88",
+		Name:      "Welcome",
+		Subject:   "Welcome aboard",
+		HTMLBody:  "<p>Hello</p>",
+		TextBody:  "Hello",
+		Category:  "Onboarding",
+		CreatedAt: datetime,
+		UpdatedAt: datetime,
+	}
+}
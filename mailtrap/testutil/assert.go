@@ -0,0 +1,66 @@
+package testutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bennovw/mailtrap-go/mailtrap"
+)
+
+// MessageMatcher reports whether the message matches the expectation.
+type MessageMatcher func(*mailtrap.Message) bool
+
+// BySubject matches messages with exactly the given subject.
+func BySubject(subject string) MessageMatcher {
+	return func(m *mailtrap.Message) bool {
+		return m.Subject == subject
+	}
+}
+
+// ByRecipient matches messages sent to the given email address, ignoring case.
+func ByRecipient(email string) MessageMatcher {
+	return func(m *mailtrap.Message) bool {
+		return strings.EqualFold(m.ToEmail, email)
+	}
+}
+
+// ByCategory matches messages with the given category.
+func ByCategory(category string) MessageMatcher {
+	return func(m *mailtrap.Message) bool {
+		return m.Category == category
+	}
+}
+
+// Messages wraps MessagesService with test assertions.
+type Messages struct {
+	*mailtrap.MessagesService
+}
+
+// AssertReceived returns the first message in the inbox satisfying the matcher.
+// If no message matches, the test fails with a list of the messages that were found.
+func (m Messages) AssertReceived(t testing.TB, accountID, inboxID int, matcher MessageMatcher) *mailtrap.Message {
+	t.Helper()
+
+	messages, _, err := m.List(accountID, inboxID)
+	if err != nil {
+		t.Fatalf("AssertReceived: unable to list messages of inbox %d: %v", inboxID, err)
+		return nil
+	}
+
+	for _, msg := range messages {
+		if matcher(msg) {
+			return msg
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "AssertReceived: no matching message in inbox %d, found %d message(s):", inboxID, len(messages))
+	for _, msg := range messages {
+		fmt.Fprintf(&b, "\n\t- id=%d subject=%q from=%q to=%q category=%q",
+			msg.ID, msg.Subject, msg.FromEmail, msg.ToEmail, msg.Category)
+	}
+	t.Fatalf("%s", b.String())
+
+	return nil
+}
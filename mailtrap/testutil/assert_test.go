@@ -0,0 +1,82 @@
+package testutil
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// recorderTB records fatal failures instead of stopping the test.
+type recorderTB struct {
+	testing.TB
+	failed  bool
+	message string
+}
+
+func (r *recorderTB) Helper() {}
+
+func (r *recorderTB) Fatalf(format string, args ...interface{}) {
+	r.failed = true
+	r.message = fmt.Sprintf(format, args...)
+}
+
+const messagesJSON = `[
+	{"id":1,"subject":"Welcome","from_email":"ches@example.com","to_email":"john@example.com","category":"Onboarding"},
+	{"id":2,"subject":"Password reset","from_email":"ches@example.com","to_email":"mary@example.com","category":"Security"}
+]`
+
+func TestMessages_AssertReceived(t *testing.T) {
+	client, mux := setupTestingClient(t)
+	mux.HandleFunc("/accounts/1/inboxes/2/messages", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, messagesJSON)
+	})
+
+	tests := map[string]struct {
+		matcher MessageMatcher
+		wantID  int
+	}{
+		"subject":   {BySubject("Password reset"), 2},
+		"recipient": {ByRecipient("JOHN@example.com"), 1},
+		"category":  {ByCategory("Security"), 2},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			msg := Messages{client.Messages}.AssertReceived(t, 1, 2, tt.matcher)
+			if msg == nil || msg.ID != tt.wantID {
+				t.Errorf("AssertReceived returned %+v, want message %d", msg, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestMessages_AssertReceived_noMatch(t *testing.T) {
+	client, mux := setupTestingClient(t)
+	mux.HandleFunc("/accounts/1/inboxes/2/messages", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, messagesJSON)
+	})
+
+	rec := &recorderTB{TB: t}
+	msg := Messages{client.Messages}.AssertReceived(rec, 1, 2, BySubject("Invoice"))
+	if msg != nil {
+		t.Errorf("AssertReceived returned %+v, want nil", msg)
+	}
+	if !rec.failed {
+		t.Fatal("AssertReceived did not fail the test")
+	}
+	for _, want := range []string{"found 2 message(s)", `subject="Welcome"`, `subject="Password reset"`, `to="mary@example.com"`} {
+		if !strings.Contains(rec.message, want) {
+			t.Errorf("AssertReceived failure message %q does not contain %q", rec.message, want)
+		}
+	}
+}
+
+func TestMessages_AssertReceived_listError(t *testing.T) {
+	client, _ := setupTestingClient(t)
+
+	rec := &recorderTB{TB: t}
+	Messages{client.Messages}.AssertReceived(rec, 1, 2, BySubject("Welcome"))
+	if !rec.failed || !strings.Contains(rec.message, "unable to list messages") {
+		t.Errorf("AssertReceived failure message = %q, want list error", rec.message)
+	}
+}
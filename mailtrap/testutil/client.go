@@ -0,0 +1,69 @@
+package testutil
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/bennovw/mailtrap-go/mailtrap"
+)
+
+// TestingClient wraps mailtrap.TestingClient and deletes the inboxes created
+// with CreateInbox when the test and all its subtests complete.
+type TestingClient struct {
+	*mailtrap.TestingClient
+
+	mu              sync.Mutex
+	createdInboxIDs []createdInbox
+}
+
+type createdInbox struct {
+	accountID, inboxID int
+}
+
+// NewTestingClientForTest creates a testing API client bound to the test. The test fails immediately
+// if the client cannot be created. Inboxes created with CreateInbox are deleted by t.Cleanup,
+// even if the test fails.
+func NewTestingClientForTest(t testing.TB, apiKey string, opts ...mailtrap.Option) *TestingClient {
+	t.Helper()
+
+	client, err := mailtrap.NewTestingClient(apiKey, opts...)
+	if err != nil {
+		t.Fatalf("NewTestingClientForTest: unable to create client: %v", err)
+		return nil
+	}
+
+	c := &TestingClient{TestingClient: client}
+	t.Cleanup(func() {
+		c.cleanup(t)
+	})
+
+	return c
+}
+
+// CreateInbox creates an inbox in the project and registers it for deletion after the test.
+func (c *TestingClient) CreateInbox(accountID, projectID int, name string) (*mailtrap.Inbox, *mailtrap.Response, error) {
+	inbox, res, err := c.Inboxes.Create(accountID, projectID, name)
+	if err != nil {
+		return nil, res, err
+	}
+
+	c.mu.Lock()
+	c.createdInboxIDs = append(c.createdInboxIDs, createdInbox{accountID: accountID, inboxID: inbox.ID})
+	c.mu.Unlock()
+
+	return inbox, res, nil
+}
+
+// cleanup deletes the created inboxes in reverse order of creation.
+func (c *TestingClient) cleanup(t testing.TB) {
+	c.mu.Lock()
+	created := c.createdInboxIDs
+	c.createdInboxIDs = nil
+	c.mu.Unlock()
+
+	for i := len(created) - 1; i >= 0; i-- {
+		if _, err := c.Inboxes.Delete(created[i].accountID, created[i].inboxID); err != nil {
+			t.Errorf("cleanup: unable to delete inbox %d: %v", created[i].inboxID, err)
+		}
+	}
+}
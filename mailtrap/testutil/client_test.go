@@ -0,0 +1,109 @@
+package testutil
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bennovw/mailtrap-go/mailtrap"
+)
+
+// cleanupTB collects the cleanup functions and failures instead of reporting them to the test.
+type cleanupTB struct {
+	testing.TB
+	cleanups []func()
+	failed   bool
+	errors   []string
+}
+
+func (c *cleanupTB) Helper() {}
+
+func (c *cleanupTB) Cleanup(fn func()) {
+	c.cleanups = append(c.cleanups, fn)
+}
+
+func (c *cleanupTB) Fail() {
+	c.failed = true
+}
+
+func (c *cleanupTB) Errorf(format string, args ...interface{}) {
+	c.failed = true
+	c.errors = append(c.errors, fmt.Sprintf(format, args...))
+}
+
+func (c *cleanupTB) runCleanups() {
+	for i := len(c.cleanups) - 1; i >= 0; i-- {
+		c.cleanups[i]()
+	}
+}
+
+func TestNewTestingClientForTest(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		deleted []string
+		nextID  = 10
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/accounts/1/projects/2/inboxes", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		nextID++
+		fmt.Fprintf(w, `{"id":%d}`, nextID)
+	})
+	mux.HandleFunc("/accounts/1/inboxes/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("Request method: %v, want %v", r.Method, http.MethodDelete)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/accounts/1/inboxes/"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	tb := &cleanupTB{TB: t}
+	client := NewTestingClientForTest(tb, "api-token", mailtrap.WithBaseURL(server.URL))
+	for _, name := range []string{"first", "second"} {
+		if _, _, err := client.CreateInbox(1, 2, name); err != nil {
+			t.Fatalf("CreateInbox returned error: %v", err)
+		}
+	}
+	tb.Fail()
+
+	if len(deleted) != 0 {
+		t.Fatalf("inboxes deleted before the cleanup: %v", deleted)
+	}
+	tb.runCleanups()
+
+	if want := []string{"12", "11"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("cleanup deleted inboxes %v, want %v", deleted, want)
+	}
+	if len(tb.errors) != 0 {
+		t.Errorf("cleanup reported errors: %v", tb.errors)
+	}
+}
+
+func TestNewTestingClientForTest_deleteFails(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/accounts/1/projects/2/inboxes", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":11}`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	tb := &cleanupTB{TB: t}
+	client := NewTestingClientForTest(tb, "api-token", mailtrap.WithBaseURL(server.URL))
+	if _, _, err := client.CreateInbox(1, 2, "first"); err != nil {
+		t.Fatalf("CreateInbox returned error: %v", err)
+	}
+	tb.runCleanups()
+
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "unable to delete inbox 11") {
+		t.Errorf("cleanup reported errors %v, want a failure for inbox 11", tb.errors)
+	}
+}
@@ -0,0 +1,44 @@
+// Package testutil provides helpers for writing tests against the Mailtrap testing API.
+package testutil
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bennovw/mailtrap-go/mailtrap"
+)
+
+// PollInterval is the interval at which the helpers poll the inbox for new messages.
+var PollInterval = time.Second
+
+// SendAndWait sends the request via sendClient and waits until the message with a matching
+// subject and from address arrives in the inbox, or the context is done.
+func SendAndWait(
+	ctx context.Context,
+	sendClient mailtrap.SendingClient,
+	testClient *mailtrap.TestingClient,
+	req *mailtrap.SendEmailRequest,
+	accountID, inboxID int,
+) (*mailtrap.Message, error) {
+	if sendClient == nil || testClient == nil {
+		return nil, errors.New("sending and testing clients are required")
+	}
+	if req == nil {
+		return nil, errors.New("request `SendEmailRequest` is mandatory")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, _, err := sendClient.Send(ctx, req); err != nil {
+		return nil, err
+	}
+
+	msg, _, err := testClient.Messages.WaitForMessage(ctx, accountID, inboxID, PollInterval, func(m *mailtrap.Message) bool {
+		return m.Subject == req.Subject && strings.EqualFold(m.FromEmail, req.From.Email)
+	})
+
+	return msg, err
+}
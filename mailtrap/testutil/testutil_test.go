@@ -0,0 +1,135 @@
+package testutil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bennovw/mailtrap-go/mailtrap"
+)
+
+// fakeSendingClient records sent requests instead of calling the API.
+type fakeSendingClient struct {
+	mailtrap.SendingClient
+	sent []*mailtrap.SendEmailRequest
+}
+
+func (c *fakeSendingClient) Send(_ context.Context, req *mailtrap.SendEmailRequest) (*mailtrap.SendEmailResponse, *mailtrap.Response, error) {
+	c.sent = append(c.sent, req)
+	return &mailtrap.SendEmailResponse{Success: true}, nil, nil
+}
+
+// setupTestingClient sets up a test HTTP server for the testing API client.
+func setupTestingClient(t *testing.T) (*mailtrap.TestingClient, *http.ServeMux) {
+	t.Helper()
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := mailtrap.NewTestingClient("api-token", mailtrap.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewTestingClient returned error: %v", err)
+	}
+
+	return client, mux
+}
+
+func setPollInterval(t *testing.T, d time.Duration) {
+	t.Helper()
+	prev := PollInterval
+	PollInterval = d
+	t.Cleanup(func() { PollInterval = prev })
+}
+
+func emailRequest() *mailtrap.SendEmailRequest {
+	return &mailtrap.SendEmailRequest{
+		From:    mailtrap.EmailAddress{Email: "ches@example.com"},
+		To:      []mailtrap.EmailAddress{{Email: "john@example.com"}},
+		Subject: "Order confirmation",
+		Text:    "Thanks for your order",
+	}
+}
+
+func TestSendAndWait(t *testing.T) {
+	setPollInterval(t, time.Millisecond)
+	testClient, mux := setupTestingClient(t)
+	sendClient := &fakeSendingClient{}
+
+	var calls int
+	mux.HandleFunc("/accounts/1/inboxes/2/messages", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		messages := []*mailtrap.Message{{ID: 1, Subject: "Order confirmation", FromEmail: "other@example.com"}}
+		if calls > 1 {
+			messages = append(messages, &mailtrap.Message{ID: 2, Subject: "Order confirmation", FromEmail: "Ches@example.com"})
+		}
+		resp, _ := json.Marshal(messages)
+		fmt.Fprint(w, string(resp))
+	})
+
+	msg, err := SendAndWait(context.Background(), sendClient, testClient, emailRequest(), 1, 2)
+	if err != nil {
+		t.Fatalf("SendAndWait returned error: %v", err)
+	}
+	if msg.ID != 2 {
+		t.Errorf("SendAndWait returned message %d, expected 2", msg.ID)
+	}
+	if len(sendClient.sent) != 1 {
+		t.Errorf("SendAndWait sent %d emails, expected 1", len(sendClient.sent))
+	}
+}
+
+func TestSendAndWait_cancelled(t *testing.T) {
+	setPollInterval(t, time.Millisecond)
+	testClient, mux := setupTestingClient(t)
+	sendClient := &fakeSendingClient{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls int
+	mux.HandleFunc("/accounts/1/inboxes/2/messages", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 3 {
+			cancel()
+		}
+		fmt.Fprint(w, `[]`)
+	})
+
+	_, err := SendAndWait(ctx, sendClient, testClient, emailRequest(), 1, 2)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("SendAndWait returned error %v, expected %v", err, context.Canceled)
+	}
+	if calls < 3 {
+		t.Errorf("SendAndWait polled %d times, expected at least 3", calls)
+	}
+
+	_, err = SendAndWait(ctx, sendClient, testClient, emailRequest(), 1, 2)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("SendAndWait returned error %v, expected %v", err, context.Canceled)
+	}
+	if len(sendClient.sent) != 1 {
+		t.Errorf("SendAndWait sent %d emails after cancellation, expected 1", len(sendClient.sent))
+	}
+}
+
+func TestSendAndWait_deadlineExceeded(t *testing.T) {
+	setPollInterval(t, time.Millisecond)
+	testClient, mux := setupTestingClient(t)
+
+	mux.HandleFunc("/accounts/1/inboxes/2/messages", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := SendAndWait(ctx, &fakeSendingClient{}, testClient, emailRequest(), 1, 2)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SendAndWait returned error %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
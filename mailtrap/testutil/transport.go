@@ -0,0 +1,173 @@
+package testutil
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bennovw/mailtrap-go/mailtrap"
+)
+
+// apiPrefix is the path prefix of all API endpoints. Stub paths may omit it.
+const apiPrefix = "/api"
+
+// MockTransport is an http.RoundTripper that answers requests with pre-registered stub responses
+// and records every request it receives. Requests without a matching stub get a 404 response.
+//
+//	mock := testutil.NewMockTransport()
+//	mock.On("POST", "/send").Return(200, `{"success":true,"message_ids":["1"]}`)
+//	client, _ := mailtrap.NewSendingClient("api-key", mock.Option())
+type MockTransport struct {
+	mu    sync.Mutex
+	stubs []*Stub
+	calls []Call
+}
+
+// Stub is a response registered with MockTransport.On.
+type Stub struct {
+	method string
+	path   string
+	status int
+	body   string
+}
+
+// Call is a request received by MockTransport.
+type Call struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   []byte
+}
+
+var _ http.RoundTripper = &MockTransport{}
+
+// NewMockTransport creates a MockTransport without stubs.
+func NewMockTransport() *MockTransport {
+	return &MockTransport{}
+}
+
+// Option returns a client option that sends all requests of the client through the mock.
+func (m *MockTransport) Option() mailtrap.Option {
+	return mailtrap.WithTransport(m)
+}
+
+// On registers a stub for requests with the method and path. The path may omit the /api prefix.
+// The stub answers with 200 and an empty JSON object until Return is called.
+// A later stub for the same method and path replaces the earlier one.
+func (m *MockTransport) On(method, path string) *Stub {
+	s := &Stub{method: method, path: path, status: http.StatusOK, body: "{}"}
+
+	m.mu.Lock()
+	m.stubs = append(m.stubs, s)
+	m.mu.Unlock()
+
+	return s
+}
+
+// Return sets the status code and body of the stub response.
+func (s *Stub) Return(status int, body string) *Stub {
+	s.status = status
+	s.body = body
+	return s
+}
+
+func (s *Stub) matches(method, path string) bool {
+	return s.method == method && (s.path == path || apiPrefix+s.path == path)
+}
+
+// RoundTrip records the request and answers it with the matching stub response.
+func (m *MockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		if err = req.Body.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: req.Method, Path: req.URL.Path, Header: req.Header.Clone(), Body: body})
+	var stub *Stub
+	for i := len(m.stubs) - 1; i >= 0; i-- {
+		if m.stubs[i].matches(req.Method, req.URL.Path) {
+			stub = m.stubs[i]
+			break
+		}
+	}
+	m.mu.Unlock()
+
+	status, respBody := http.StatusNotFound, fmt.Sprintf(`{"error":"no stub for %s %s"}`, req.Method, req.URL.Path)
+	if stub != nil {
+		status, respBody = stub.status, stub.body
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+// Calls returns the requests received so far, in order.
+func (m *MockTransport) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// AssertCalled fails the test if no request with the method and path was received.
+func (m *MockTransport) AssertCalled(t testing.TB, method, path string) {
+	t.Helper()
+
+	if m.count(method, path) == 0 {
+		t.Errorf("AssertCalled: no %s %s request, received:%s", method, path, m.describeCalls())
+	}
+}
+
+// AssertCalledTimes fails the test unless exactly n requests with the method and path were received.
+func (m *MockTransport) AssertCalledTimes(t testing.TB, n int, method, path string) {
+	t.Helper()
+
+	if got := m.count(method, path); got != n {
+		t.Errorf("AssertCalledTimes: got %d %s %s request(s), want %d, received:%s",
+			got, method, path, n, m.describeCalls())
+	}
+}
+
+func (m *MockTransport) count(method, path string) int {
+	s := Stub{method: method, path: path}
+	n := 0
+	for _, c := range m.Calls() {
+		if s.matches(c.Method, c.Path) {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (m *MockTransport) describeCalls() string {
+	var b bytes.Buffer
+	for _, c := range m.Calls() {
+		fmt.Fprintf(&b, "\n\t- %s %s", c.Method, c.Path)
+	}
+	if b.Len() == 0 {
+		return " none"
+	}
+
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bennovw/mailtrap-go/mailtrap"
+)
+
+func TestMockTransport(t *testing.T) {
+	mock := NewMockTransport()
+	mock.On("POST", "/send").Return(200, `{"success":true,"message_ids":["1"]}`)
+
+	client, err := mailtrap.NewSendingClient("api-token", mock.Option())
+	if err != nil {
+		t.Fatalf("NewSendingClient returned error: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, _, err := client.Send(context.Background(), emailRequest())
+		if err != nil {
+			t.Fatalf("Send returned error: %v", err)
+		}
+		want := &mailtrap.SendEmailResponse{Success: true, MessageIDs: []string{"1"}}
+		if !reflect.DeepEqual(resp, want) {
+			t.Errorf("Send returned %+v, want %+v", resp, want)
+		}
+	}
+
+	mock.AssertCalled(t, "POST", "/send")
+	mock.AssertCalled(t, "POST", "/api/send")
+	mock.AssertCalledTimes(t, 2, "POST", "/send")
+
+	calls := mock.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("Calls returned %d calls, want 2", len(calls))
+	}
+	if !strings.Contains(string(calls[0].Body), `"subject":"Order confirmation"`) {
+		t.Errorf("Calls()[0].Body = %s, want the request body", calls[0].Body)
+	}
+	if got := calls[0].Header.Get("Authorization"); got != "Bearer api-token" {
+		t.Errorf("Calls()[0] Authorization header = %q, want %q", got, "Bearer api-token")
+	}
+}
+
+func TestMockTransport_noStub(t *testing.T) {
+	mock := NewMockTransport()
+	client, err := mailtrap.NewTestingClient("api-token", mock.Option())
+	if err != nil {
+		t.Fatalf("NewTestingClient returned error: %v", err)
+	}
+
+	_, _, err = client.Accounts.List()
+	if !errors.Is(err, mailtrap.ErrNotFound) {
+		t.Errorf("Accounts.List returned error %v, want %v", err, mailtrap.ErrNotFound)
+	}
+
+	tb := &cleanupTB{TB: t}
+	mock.AssertCalled(tb, "POST", "/send")
+	mock.AssertCalledTimes(tb, 2, "GET", "/accounts")
+	if len(tb.errors) != 2 {
+		t.Fatalf("assertions reported %d errors, want 2: %v", len(tb.errors), tb.errors)
+	}
+	if !strings.Contains(tb.errors[1], "got 1 GET /accounts request(s), want 2") {
+		t.Errorf("AssertCalledTimes reported %q", tb.errors[1])
+	}
+}
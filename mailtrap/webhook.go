@@ -29,6 +29,59 @@ type Event struct {
 	URL             string            `json:"url"`
 }
 
+// WebhookEventType is the type of a webhook event, as sent in Event.Event.
+type WebhookEventType string
+
+// Webhook event types.
+const (
+	EventDelivered     WebhookEventType = "delivery"
+	EventSoftBounce    WebhookEventType = "soft bounce"
+	EventHardBounce    WebhookEventType = "bounce"
+	EventOpened        WebhookEventType = "open"
+	EventClicked       WebhookEventType = "click"
+	EventUnsubscribed  WebhookEventType = "unsubscribe"
+	EventSpamComplaint WebhookEventType = "spam"
+	EventRejected      WebhookEventType = "reject"
+)
+
+// AllWebhookEventTypes lists all known webhook event types.
+var AllWebhookEventTypes = []WebhookEventType{
+	EventDelivered,
+	EventSoftBounce,
+	EventHardBounce,
+	EventOpened,
+	EventClicked,
+	EventUnsubscribed,
+	EventSpamComplaint,
+	EventRejected,
+}
+
+// Type returns the type of the event.
+func (e *Event) Type() WebhookEventType {
+	return WebhookEventType(e.Event)
+}
+
+// IsBounce reports whether the event is a soft or hard bounce.
+func (e *Event) IsBounce() bool {
+	return e.Type() == EventSoftBounce || e.Type() == EventHardBounce
+}
+
+// IsUserAction reports whether the event was triggered by the recipient:
+// an open, a click, an unsubscribe or a spam complaint.
+func (e *Event) IsUserAction() bool {
+	switch e.Type() {
+	case EventOpened, EventClicked, EventUnsubscribed, EventSpamComplaint:
+		return true
+	}
+
+	return false
+}
+
+// IsDelivery reports whether the event is a successful delivery.
+func (e *Event) IsDelivery() bool {
+	return e.Type() == EventDelivered
+}
+
 func DecodeWebhook(r io.Reader) (*Events, error) {
 	e := new(Events)
 	if err := json.NewDecoder(r).Decode(&e); err != nil {
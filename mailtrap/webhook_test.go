@@ -56,3 +56,43 @@ func TestWebhook_DecodeWebhook(t *testing.T) {
 		t.Error("DecodeWebhook err = nil, want error")
 	}
 }
+
+func TestWebhookEventTypes(t *testing.T) {
+	tests := []struct {
+		eventType                      WebhookEventType
+		bounce, userAction, isDelivery bool
+	}{
+		{eventType: EventDelivered, isDelivery: true},
+		{eventType: EventSoftBounce, bounce: true},
+		{eventType: EventHardBounce, bounce: true},
+		{eventType: EventOpened, userAction: true},
+		{eventType: EventClicked, userAction: true},
+		{eventType: EventUnsubscribed, userAction: true},
+		{eventType: EventSpamComplaint, userAction: true},
+		{eventType: EventRejected},
+	}
+
+	if len(AllWebhookEventTypes) != len(tests) {
+		t.Errorf("AllWebhookEventTypes has %d types, want %d", len(AllWebhookEventTypes), len(tests))
+	}
+	for _, tt := range tests {
+		found := false
+		for _, et := range AllWebhookEventTypes {
+			found = found || et == tt.eventType
+		}
+		if !found {
+			t.Errorf("AllWebhookEventTypes does not contain %q", tt.eventType)
+		}
+
+		e := &Event{Event: string(tt.eventType)}
+		if got := e.IsBounce(); got != tt.bounce {
+			t.Errorf("Event{%q}.IsBounce() = %v, want %v", tt.eventType, got, tt.bounce)
+		}
+		if got := e.IsUserAction(); got != tt.userAction {
+			t.Errorf("Event{%q}.IsUserAction() = %v, want %v", tt.eventType, got, tt.userAction)
+		}
+		if got := e.IsDelivery(); got != tt.isDelivery {
+			t.Errorf("Event{%q}.IsDelivery() = %v, want %v", tt.eventType, got, tt.isDelivery)
+		}
+	}
+}